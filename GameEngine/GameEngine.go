@@ -31,6 +31,21 @@ type Context struct {
 	ScrnHeight        float64
 	lastTick          time.Time
 	screenXYtransform TransformFunc
+	camera            *Camera
+	queue             []drawCommand // deferred draws
+	screenLayers      map[int]bool
+	colour            Colour        // draw colour
+	target            *RenderTarget // drawn to instead of the screen if set
+	blend             BlendMode
+	effects           []PostEffect
+	frame             *RenderTarget // drawn here first for post effects, indexed colour or SetFrameBuffer
+	post              *RenderTarget // the frame with the effects applied
+	lastPresent       time.Time
+	indexed           *indexedFrame // set in indexed colour
+	index             uint8         // draw colour in indexed colour
+	keepFrame         bool          // use the frame buffer even without post effects or indexed colour
+	texture           *sdl.Texture  // the frame goes to the screen through this
+	fine              []fineRect    // draws smaller than a block, put over the frame buffer at Present
 }
 
 // New - create the GameEngine and initialises
@@ -60,11 +75,25 @@ func New(b, sw, sh float64, title string, tf TransformFunc) *Context {
 		os.Exit(2)
 	}
 
+	// drawing goes into a frame buffer, a pixel per block, sent to the screen in one go at Present
+	ctx.texture, err = ctx.Renderer.CreateTexture(sdl.PIXELFORMAT_RGBA32, sdl.TEXTUREACCESS_STREAMING,
+		int32(sw), int32(sh))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create frame texture, drawing blocks one at a time: %s\n", err)
+		ctx.texture = nil
+	} else {
+		ctx.texture.SetBlendMode(sdl.BLENDMODE_NONE)
+	}
+	ctx.SetFrameBuffer(true)
+
 	return &ctx
 }
 
 // Destroy - cleans up window and renderer
 func (c *Context) Destroy() {
+	if c.texture != nil {
+		c.texture.Destroy()
+	}
 	c.Window.Destroy()
 	c.Renderer.Destroy()
 	// c.font.Close()
@@ -86,9 +115,9 @@ func RandIntN(i float64) float64 {
 	return float64(rand.Intn(int(i)))
 }
 
-// NewSdlColor - takes floats and returs an sdl suitalbe color object
+// NewSdlColor - takes floats and returs an sdl suitalbe color object. Values are clamped to 0-255
 func NewSdlColor(r, g, b, a float64) sdl.Color {
-	return sdl.Color{uint8(r), uint8(g), uint8(b), uint8(a)}
+	return sdl.Color{toByte(r), toByte(g), toByte(b), toByte(a)}
 }
 
 // Colour struct for float64 colour values
@@ -118,9 +147,9 @@ func (c Colour) ToSDLColor() sdl.Color {
 	return NewSdlColor(c.R, c.G, c.B, c.A)
 }
 
-// Unpack - transforms Colour struct to 4 uint8 values
+// Unpack - transforms Colour struct to 4 uint8 values, clamped to 0-255 and rounded
 func (c Colour) Unpack() (uint8, uint8, uint8, uint8) {
-	return uint8(c.R), uint8(c.G), uint8(c.B), uint8(c.A)
+	return toByte(c.R), toByte(c.G), toByte(c.B), toByte(c.A)
 }
 
 // V2D - struct for holding a 2D vector
@@ -362,19 +391,43 @@ func (c *Context) topFlatTriangle(x1, y1, x2, y2, x3, y3 float64) {
 	}
 }
 
-// Clear renderer
+// Clear renderer, or the render target if one is set, to the draw colour
 func (c *Context) Clear() {
+	if c.target == nil {
+		c.fine = c.fine[:0]
+	}
+	if c.target == nil && c.indexed != nil {
+		c.indexed.clear(c.index)
+		return
+	}
+	if t := c.canvas(); t != nil {
+		t.Clear(c.colour)
+		return
+	}
 	c.Renderer.Clear()
 }
 
-// Present - Renders all to screen
+// Present - Renders all to screen, running any deferred draws first
 func (c *Context) Present() {
+	c.Flush()
+	if c.frame != nil {
+		elapsed := c.frameElapsed()
+		if c.indexed != nil {
+			c.indexed.render(c.frame, elapsed)
+		}
+		c.applyPostEffects(elapsed)
+		c.drawFine()
+	}
 	c.Renderer.Present()
 }
 
 // SetDrawColor for next use to Colour struct
 func (c *Context) SetDrawColor(rgba Colour) {
 	// color := &sdl.Color{R: uint8(r), G: uint8(g), B: uint8(b), A: uint8(a)}
+	c.colour = rgba
+	if c.indexed != nil {
+		c.index = c.indexed.nearest(rgba)
+	}
 	c.Renderer.SetDrawColor(rgba.Unpack())
 }
 
@@ -475,17 +528,69 @@ func (c *Context) DrawFillCircle(x, y, radius float64) {
 	}
 }
 
-// Point - Draws a blocky point transformed to screen with optional transform applied from func stored in Context
+// Point - Draws a blocky point transformed to screen with optional transform applied from func stored in Context,
+// then through the camera if one is set
 func (c *Context) Point(x0, y0 float64) {
 	if c.screenXYtransform != nil {
 		x0, y0 = c.screenXYtransform(x0, y0)
 	}
-	c.Renderer.FillRect(NewRect(x0*c.Blocks, y0*c.Blocks, c.Blocks, c.Blocks))
+	if c.camera != nil {
+		c.cameraPoint(x0, y0, 1)
+		return
+	}
+	p := c.scale()
+	c.fill(x0*p, y0*p, p, p)
+}
+
+// ScreenTransform - the transform func the context was made with, or nil
+func (c *Context) ScreenTransform() TransformFunc {
+	return c.screenXYtransform
 }
 
-// PointScale - Draws a blocky point but scaled down by a factore (used mainly in text drawing) (blocks)
+// PointScale - Draws a blocky point but scaled down by a factore (used mainly in text drawing) (blocks). The frame
+// buffer only holds whole blocks, so points smaller than a block drawn to it go straight on the screen over it at
+// Present, after any post effects. A render target keeps a pixel per block so they fill a whole block there
 func (c *Context) PointScale(x0, y0, scale float64) {
-	c.Renderer.FillRect(NewRect(x0*c.Blocks/scale, y0*c.Blocks/scale, c.Blocks/scale, c.Blocks/scale))
+	if c.screenXYtransform != nil {
+		x0, y0 = c.screenXYtransform(x0/scale, y0/scale)
+		x0, y0 = x0*scale, y0*scale
+	}
+	if c.camera != nil {
+		c.cameraPoint(x0/scale, y0/scale, 1/scale)
+		return
+	}
+	if scale > 1 && c.target == nil && c.frame != nil {
+		p := c.Blocks
+		c.fillFine(x0*p/scale, y0*p/scale, p/scale, p/scale)
+		return
+	}
+	p := c.scale()
+	c.fill(x0*p/scale, y0*p/scale, p/scale, p/scale)
+}
+
+// cameraPoint - draws a world point size blocks across through the camera, clipped to its viewport. Edges are
+// rounded to whole pixels so zoomed points meet without gaps
+func (c *Context) cameraPoint(x0, y0, size float64) {
+	cam := c.camera
+	sx, sy := cam.WorldToScreen(x0, y0)
+	size *= cam.Zoom
+	p := c.scale()
+	left, top := math.Floor(sx*p), math.Floor(sy*p)
+	right, bottom := math.Floor((sx+size)*p), math.Floor((sy+size)*p)
+	if cam.Rotation != 0 {
+		// turned points don't line up, so overlap them a little to hide the cracks
+		right++
+		bottom++
+	}
+	v := cam.Viewport
+	left = math.Max(left, math.Floor(v.X*p))
+	top = math.Max(top, math.Floor(v.Y*p))
+	right = math.Min(right, math.Floor((v.X+v.W)*p))
+	bottom = math.Min(bottom, math.Floor((v.Y+v.H)*p))
+	if right <= left || bottom <= top {
+		return
+	}
+	c.fill(left, top, right-left, bottom-top)
 }
 
 // Elapsed - calculates the elapsed time between updates
@@ -513,8 +618,9 @@ func Sign(s float64) float64 {
 // Sprite struct
 type Sprite struct {
 	image.Image
-	W float64
-	H float64
+	W    float64
+	H    float64
+	mask *Mask // built by CollisionMask
 }
 
 // NewSprite - loads and builds a new sprite given a filename. Returns pointer to sprite structure
@@ -532,7 +638,7 @@ func NewSprite(filename string) (s *Sprite, err error) {
 		return
 	}
 	bounds := i.Bounds()
-	s = &Sprite{i, float64(bounds.Max.X - bounds.Min.X), float64(bounds.Max.Y - bounds.Min.Y)}
+	s = &Sprite{Image: i, W: float64(bounds.Max.X - bounds.Min.X), H: float64(bounds.Max.Y - bounds.Min.Y)}
 	return
 }
 
@@ -543,7 +649,7 @@ func (s *Sprite) DrawSprite(c *Context, x, y float64) {
 			r, g, b, a := s.At(int(i), int(j)).RGBA()
 			// no blending for now!
 			if a > 0 {
-				c.Renderer.SetDrawColor(uint8(r), uint8(g), uint8(b), uint8(a))
+				c.SetDrawColor(NewColour(float64(uint8(r)), float64(uint8(g)), float64(uint8(b)), float64(uint8(a))))
 				c.Point(x+i, y+j)
 			}
 		}
@@ -557,21 +663,27 @@ func (s *Sprite) DrawPartialSprite(c *Context, x, y, ox, oy, w, h float64) {
 			r, g, b, a := s.At(int(i), int(j)).RGBA()
 			// no blending for now!
 			if a > 0 {
-				c.Renderer.SetDrawColor(uint8(r), uint8(g), uint8(b), uint8(a))
+				c.SetDrawColor(NewColour(float64(uint8(r)), float64(uint8(g)), float64(uint8(b)), float64(uint8(a))))
 				c.Point(x+i-ox, y+j-oy)
 			}
 		}
 	}
 }
 
-// SampleSprite - Samples from normal x, y of sprite
+// SampleSprite - Samples from normal x, y of sprite. Coords outside 0-1 wrap around (tile). Returns 0-255 colour
 func (s *Sprite) SampleSprite(nx, ny float64) (rgba Colour) {
+	nx -= math.Floor(nx)
+	ny -= math.Floor(ny)
+	x := int(math.Trunc(nx * s.W))
+	y := int(math.Trunc(ny * s.H))
+	return s.ColourAt(x, y)
+}
+
+// ColourAt - returns colour (0-255, not premultiplied) of sprite pixel x, y counted from the top left
+func (s *Sprite) ColourAt(x, y int) Colour {
 	bounds := s.Bounds()
-	x := int(math.Trunc(nx * float64(bounds.Max.X-bounds.Min.X)))
-	y := int(math.Trunc(ny * float64(bounds.Max.Y-bounds.Min.Y)))
-	r, g, b, a := s.At(x, y).RGBA()
-	rgba = NewColour(float64(r), float64(g), float64(b), float64(a))
-	return
+	c := color.NRGBAModel.Convert(s.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.NRGBA)
+	return NewColour(float64(c.R), float64(c.G), float64(c.B), float64(c.A))
 }
 
 // SpriteSheet -
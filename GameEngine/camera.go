@@ -0,0 +1,164 @@
+package GameEngine
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Viewport - a rectangle of the screen, in blocks
+type Viewport struct {
+	X, Y, W, H float64
+}
+
+// Contains - true if screen point x, y is inside the viewport
+func (v Viewport) Contains(x, y float64) bool {
+	return x >= v.X && y >= v.Y && x < v.X+v.W && y < v.Y+v.H
+}
+
+// Camera - a view of the world drawn into a viewport of the screen. While a camera is set on the Context (see
+// SetCamera) everything drawn is in world coordinates and is moved, zoomed and turned to match it, and clipped to
+// its viewport. Use several cameras with different viewports for split screen
+type Camera struct {
+	Pos      P2D     // world point at the centre of the viewport
+	Zoom     float64 // blocks per world unit
+	Rotation float64 // radians, turning the view clockwise
+	Viewport Viewport
+	// Deadzone - half width and height (world units) of a box round Pos the followed target can move in without
+	// the camera moving
+	Deadzone V2D
+	// Smoothing - time (Elapsed units) the camera takes to close most of the gap to its target. 0 snaps to it
+	Smoothing float64
+
+	target    P2D
+	following bool
+	bounded   bool
+	bounds    [4]float64 // min x, min y, max x, max y
+	shake     float64    // strength of shaking
+	shakeFor  float64    // time left shaking
+	shakeTime float64    // length of the shake
+	shakeOff  V2D
+	shakeRot  float64
+}
+
+// NewCamera - camera drawing into the viewport x, y, w, h (blocks) with no zoom. It looks at the middle of the
+// viewport so world and screen coordinates match to start with
+func NewCamera(x, y, w, h float64) *Camera {
+	return &Camera{
+		Pos:      P2D{X: x + w/2, Y: y + h/2},
+		Zoom:     1,
+		Viewport: Viewport{X: x, Y: y, W: w, H: h},
+	}
+}
+
+// Follow - sets the point the camera moves towards in Update. Call it every frame with the target's position
+func (cam *Camera) Follow(x, y float64) {
+	cam.target = P2D{X: x, Y: y}
+	cam.following = true
+}
+
+// Unfollow - stops the camera following anything
+func (cam *Camera) Unfollow() {
+	cam.following = false
+}
+
+// SetBounds - keeps the view inside the world rectangle minX, minY to maxX, maxY (ignoring rotation). If the
+// view is bigger than the rectangle it is centred on it
+func (cam *Camera) SetBounds(minX, minY, maxX, maxY float64) {
+	cam.bounds = [4]float64{minX, minY, maxX, maxY}
+	cam.bounded = true
+}
+
+// ClearBounds - lets the camera go anywhere
+func (cam *Camera) ClearBounds() {
+	cam.bounded = false
+}
+
+// Shake - shakes the view by up to strength blocks for duration, dying away as it goes
+func (cam *Camera) Shake(strength, duration float64) {
+	cam.shake = math.Max(cam.shake*cam.shakeFor/math.Max(cam.shakeTime, 1e-9), strength)
+	cam.shakeFor = duration
+	cam.shakeTime = duration
+}
+
+// Update - moves the camera towards the followed target, keeps it in bounds and shakes it
+func (cam *Camera) Update(elapsed float64) {
+	if cam.following {
+		goal := cam.Pos
+		// only chase the part of the target outside the dead zone
+		if d := cam.target.X - goal.X; d > cam.Deadzone.Dx {
+			goal.X += d - cam.Deadzone.Dx
+		} else if d < -cam.Deadzone.Dx {
+			goal.X += d + cam.Deadzone.Dx
+		}
+		if d := cam.target.Y - goal.Y; d > cam.Deadzone.Dy {
+			goal.Y += d - cam.Deadzone.Dy
+		} else if d < -cam.Deadzone.Dy {
+			goal.Y += d + cam.Deadzone.Dy
+		}
+		f := 1.0
+		if cam.Smoothing > 0 {
+			f = 1 - math.Exp(-elapsed/cam.Smoothing)
+		}
+		cam.Pos = cam.Pos.Add(goal.Sub(cam.Pos).Scale(f))
+	}
+	if cam.bounded {
+		hw, hh := cam.Viewport.W/cam.Zoom/2, cam.Viewport.H/cam.Zoom/2
+		cam.Pos.X = clampView(cam.Pos.X, hw, cam.bounds[0], cam.bounds[2])
+		cam.Pos.Y = clampView(cam.Pos.Y, hh, cam.bounds[1], cam.bounds[3])
+	}
+	cam.shakeOff, cam.shakeRot = V2D{}, 0
+	if cam.shakeFor > 0 {
+		cam.shakeFor -= elapsed
+		s := cam.shake * Clamp01(cam.shakeFor/cam.shakeTime)
+		cam.shakeOff = V2D{Dx: (rand.Float64()*2 - 1) * s, Dy: (rand.Float64()*2 - 1) * s}
+		cam.shakeRot = (rand.Float64()*2 - 1) * s * 0.01
+	}
+}
+
+// clampView - centre c of a view half size h kept within lo to hi
+func clampView(c, h, lo, hi float64) float64 {
+	if hi-lo < 2*h {
+		return (lo + hi) / 2
+	}
+	return Clamp(c, lo+h, hi-h)
+}
+
+// WorldToScreen - screen position (blocks) of world point x, y
+func (cam *Camera) WorldToScreen(x, y float64) (sx, sy float64) {
+	d := V2D{Dx: x - cam.Pos.X, Dy: y - cam.Pos.Y}.Rotate(-cam.Rotation - cam.shakeRot).Scale(cam.Zoom)
+	return cam.Viewport.X + cam.Viewport.W/2 + d.Dx + cam.shakeOff.Dx,
+		cam.Viewport.Y + cam.Viewport.H/2 + d.Dy + cam.shakeOff.Dy
+}
+
+// ScreenToWorld - world point under screen position x, y (blocks), e.g. for the mouse
+func (cam *Camera) ScreenToWorld(x, y float64) (wx, wy float64) {
+	d := V2D{
+		Dx: x - cam.Viewport.X - cam.Viewport.W/2 - cam.shakeOff.Dx,
+		Dy: y - cam.Viewport.Y - cam.Viewport.H/2 - cam.shakeOff.Dy,
+	}.Scale(1 / cam.Zoom).Rotate(cam.Rotation + cam.shakeRot)
+	return cam.Pos.X + d.Dx, cam.Pos.Y + d.Dy
+}
+
+// Visible - world rectangle (min x, min y, max x, max y) holding everything the camera can see, for culling
+func (cam *Camera) Visible() (x0, y0, x1, y1 float64) {
+	v := cam.Viewport
+	x0, y0 = math.Inf(1), math.Inf(1)
+	x1, y1 = math.Inf(-1), math.Inf(-1)
+	for _, p := range [4]P2D{{X: v.X, Y: v.Y}, {X: v.X + v.W, Y: v.Y}, {X: v.X, Y: v.Y + v.H}, {X: v.X + v.W, Y: v.Y + v.H}} {
+		x, y := cam.ScreenToWorld(p.X, p.Y)
+		x0, y0 = math.Min(x0, x), math.Min(y0, y)
+		x1, y1 = math.Max(x1, x), math.Max(y1, y)
+	}
+	return
+}
+
+// SetCamera - draws everything that follows through cam, until it is changed. nil goes back to drawing straight
+// to the screen
+func (c *Context) SetCamera(cam *Camera) {
+	c.camera = cam
+}
+
+// Camera - the camera being drawn through, or nil
+func (c *Context) Camera() *Camera {
+	return c.camera
+}
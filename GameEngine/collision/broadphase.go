@@ -0,0 +1,157 @@
+package collision
+
+import (
+	"math"
+
+	"github.com/kevincolyer/GameEngine/GameEngine"
+)
+
+// BroadPhase - spatial index of things by bounding box, used to find what might be colliding before doing
+// the exact (narrow phase) tests. T is whatever identifies a thing, e.g. a pointer to a game object
+type BroadPhase[T comparable] interface {
+	// Insert - adds id with bounding box box. Same as Update if already there
+	Insert(id T, box AABB)
+	// Update - moves id to box, inserting it if it isn't there
+	Update(id T, box AABB)
+	// Remove - takes id out of the index
+	Remove(id T)
+	// Len - number of things in the index
+	Len() int
+	// Query - things whose boxes overlap region
+	Query(region AABB) []T
+	// Nearest - thing whose box is closest to p and no further than maxDist away
+	Nearest(p GameEngine.P2D, maxDist float64) (T, bool)
+	// Pairs - every pair of things whose boxes overlap, each pair once
+	Pairs() [][2]T
+}
+
+// World - the area a broad phase covers. If Wrap is set the world is toroidal (like asteroids wrapScreen):
+// boxes hanging off one edge carry on at the opposite edge
+type World struct {
+	Bounds AABB
+	Wrap   bool
+}
+
+// pieces - splits box into the parts inside the world, wrapping around the edges if the world wraps
+func (w World) pieces(box AABB) []AABB {
+	if !w.Wrap || w.Bounds.W() <= 0 || w.Bounds.H() <= 0 {
+		return []AABB{box}
+	}
+	xs := wrapSpan(box.Min.X, box.Max.X, w.Bounds.Min.X, w.Bounds.Max.X)
+	ys := wrapSpan(box.Min.Y, box.Max.Y, w.Bounds.Min.Y, w.Bounds.Max.Y)
+	out := make([]AABB, 0, len(xs)*len(ys))
+	for _, x := range xs {
+		for _, y := range ys {
+			out = append(out, AABB{Min: GameEngine.P2D{X: x[0], Y: y[0]}, Max: GameEngine.P2D{X: x[1], Y: y[1]}})
+		}
+	}
+	return out
+}
+
+// overlaps - true if the boxes overlap, allowing for wrap around
+func (w World) overlaps(a, b AABB) bool {
+	for _, pa := range w.pieces(a) {
+		for _, pb := range w.pieces(b) {
+			if pa.Overlaps(pb) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// dist - distance from p to the nearest part of box, allowing for wrap around
+func (w World) dist(p GameEngine.P2D, box AABB) float64 {
+	if !w.Wrap {
+		return math.Hypot(spanDist(p.X, box.Min.X, box.Max.X, 0), spanDist(p.Y, box.Min.Y, box.Max.Y, 0))
+	}
+	return math.Hypot(
+		spanDist(p.X, box.Min.X, box.Max.X, w.Bounds.W()),
+		spanDist(p.Y, box.Min.Y, box.Max.Y, w.Bounds.H()),
+	)
+}
+
+// wrapSpan - splits min..max into at most two spans inside lo..hi, wrapping round
+func wrapSpan(min, max, lo, hi float64) [][2]float64 {
+	size := hi - lo
+	if max-min >= size {
+		return [][2]float64{{lo, hi}}
+	}
+	shift := math.Floor((min-lo)/size) * size
+	min -= shift
+	max -= shift
+	if max <= hi {
+		return [][2]float64{{min, max}}
+	}
+	return [][2]float64{{min, hi}, {lo, max - size}}
+}
+
+// spanDist - distance from v to the span min..max. If size > 0 the axis wraps round every size
+func spanDist(v, min, max, size float64) float64 {
+	if v >= min && v <= max {
+		return 0
+	}
+	if size <= 0 {
+		return math.Max(min-v, v-max)
+	}
+	if max-min >= size {
+		return 0
+	}
+	// v moved round to where it is just after min, which is inside the span if the span wraps round onto it
+	v = min + math.Mod(math.Mod(v-min, size)+size, size)
+	if v <= max {
+		return 0
+	}
+	return math.Min(v-max, min+size-v)
+}
+
+// nearest - grows a square search around p until something is found, then checks nothing closer is just
+// outside the square
+func nearest[T comparable](w World, n int, p GameEngine.P2D, maxDist, start float64, query func(AABB) []T, boxOf func(T) AABB) (best T, ok bool) {
+	if n == 0 {
+		return
+	}
+	if start <= 0 {
+		start = 1
+	}
+	for r := start; ; r *= 2 {
+		r = math.Min(r, maxDist)
+		bestDist := math.Inf(1)
+		region := AABB{Min: GameEngine.P2D{X: p.X - r, Y: p.Y - r}, Max: GameEngine.P2D{X: p.X + r, Y: p.Y + r}}
+		for _, id := range query(region) {
+			if d := w.dist(p, boxOf(id)); d <= maxDist && d < bestDist {
+				best, bestDist, ok = id, d, true
+			}
+		}
+		// anything closer than r must have been in the square
+		if (ok && bestDist <= r) || r >= maxDist {
+			return
+		}
+		ok = false
+	}
+}
+
+// pairKey - key for a pair of things
+type pairKey[T comparable] struct {
+	a, b T
+}
+
+// pairSet - collects unique pairs
+type pairSet[T comparable] struct {
+	seen  map[pairKey[T]]bool
+	pairs [][2]T
+}
+
+func (s *pairSet[T]) add(a, b T) {
+	if a == b {
+		return
+	}
+	if s.seen == nil {
+		s.seen = map[pairKey[T]]bool{}
+	}
+	if s.seen[pairKey[T]{a, b}] || s.seen[pairKey[T]{b, a}] {
+		return
+	}
+	s.seen[pairKey[T]{a, b}] = true
+	s.pairs = append(s.pairs, [2]T{a, b})
+}
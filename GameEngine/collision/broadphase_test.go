@@ -0,0 +1,232 @@
+package collision
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// broadPhases - a Grid and a QuadTree over world, the tree small enough to split
+func broadPhases(world World) map[string]BroadPhase[int] {
+	q := NewQuadTree[int](world)
+	q.MaxItems = 2
+	return map[string]BroadPhase[int]{"grid": NewGrid[int](10, world), "quadtree": q}
+}
+
+// sorted - ids in order, to compare
+func sorted(ids []int) string {
+	sort.Ints(ids)
+	return fmt.Sprint(ids)
+}
+
+func TestBroadPhaseQueryUpdateRemove(t *testing.T) {
+	world := World{Bounds: NewAABB(0, 0, 100, 100)}
+	for name, bp := range broadPhases(world) {
+		bp.Insert(1, NewAABB(5, 5, 10, 10))
+		bp.Insert(2, NewAABB(12, 12, 4, 4))
+		bp.Insert(3, NewAABB(60, 60, 30, 30))
+		bp.Insert(4, NewAABB(0, 0, 100, 100))
+		bp.Insert(5, NewAABB(95, 40, 10, 10)) // hangs off the edge
+		bp.Insert(6, NewAABB(-20, -20, 5, 5)) // outside the world
+		for _, tc := range []struct {
+			region AABB
+			want   string
+		}{
+			{NewAABB(0, 0, 10, 10), "[1 4]"},
+			{NewAABB(13, 13, 1, 1), "[1 2 4]"},
+			{NewAABB(15, 15, 1, 1), "[2 4]"},
+			{NewAABB(16, 16, 1, 1), "[4]"}, // touching isn't overlapping
+			{NewAABB(70, 70, 1, 1), "[3 4]"},
+			{NewAABB(101, 41, 2, 2), "[5]"},
+			{NewAABB(-18, -18, 1, 1), "[6]"},
+			{NewAABB(1, 41, 2, 2), "[4]"},
+		} {
+			if got := sorted(bp.Query(tc.region)); got != tc.want {
+				t.Errorf("%s: query %v got %s, want %s", name, tc.region, got, tc.want)
+			}
+		}
+		if got := fmt.Sprint(pairSorted(bp.Pairs())); got != "[[1 2] [1 4] [2 4] [3 4] [4 5]]" {
+			t.Errorf("%s: pairs %s", name, got)
+		}
+
+		// moving 1 far away, Update and Insert alike
+		bp.Update(1, NewAABB(80, 10, 5, 5))
+		bp.Insert(2, NewAABB(40, 40, 2, 2))
+		if bp.Len() != 6 {
+			t.Errorf("%s: %d after moving", name, bp.Len())
+		}
+		if got := sorted(bp.Query(NewAABB(5, 5, 15, 15))); got != "[4]" {
+			t.Errorf("%s: old places got %s", name, got)
+		}
+		if got := sorted(bp.Query(NewAABB(81, 11, 1, 1))); got != "[1 4]" {
+			t.Errorf("%s: new place got %s", name, got)
+		}
+		if got := sorted(bp.Query(NewAABB(41, 41, 1, 1))); got != "[2 4]" {
+			t.Errorf("%s: new place got %s", name, got)
+		}
+
+		bp.Remove(4)
+		bp.Remove(4)
+		bp.Remove(99)
+		if bp.Len() != 5 {
+			t.Errorf("%s: %d after removing", name, bp.Len())
+		}
+		if got := sorted(bp.Query(NewAABB(0, 0, 100, 100))); got != "[1 2 3 5]" {
+			t.Errorf("%s: after removing got %s", name, got)
+		}
+		if got := bp.Pairs(); len(got) != 0 {
+			t.Errorf("%s: pairs after removing %v", name, got)
+		}
+		if id, ok := bp.Nearest(pt(50, 50), 100); !ok || id != 2 {
+			t.Errorf("%s: nearest %v, %v", name, id, ok)
+		}
+		if _, ok := bp.Nearest(pt(20, 20), 5); ok {
+			t.Errorf("%s: nearest found something too far", name)
+		}
+	}
+}
+
+func TestBroadPhaseWrap(t *testing.T) {
+	wrapped := World{Bounds: NewAABB(0, 0, 100, 100), Wrap: true}
+	for _, world := range []World{wrapped, {Bounds: wrapped.Bounds}} {
+		for name, bp := range broadPhases(world) {
+			name = fmt.Sprintf("%s, wrap %v", name, world.Wrap)
+			bp.Insert(1, NewAABB(95, 40, 10, 10)) // over the right edge
+			bp.Insert(2, NewAABB(95, 95, 10, 10)) // over the bottom right corner
+			bp.Insert(3, NewAABB(1, 42, 2, 2))    // just inside the left edge, next to what 1 wraps onto
+			bp.Insert(4, NewAABB(50, 50, 2, 2))
+			for _, tc := range []struct {
+				region     AABB
+				want, flat string
+			}{
+				{NewAABB(96, 41, 1, 1), "[1]", "[1]"},
+				{NewAABB(0, 40, 1, 1), "[1]", "[]"},
+				{NewAABB(0, 42, 4, 2), "[1 3]", "[3]"},
+				{NewAABB(0, 0, 2, 2), "[2]", "[]"},
+				{NewAABB(98, 2, 1, 1), "[2]", "[]"},
+				{NewAABB(2, 98, 1, 1), "[2]", "[]"},
+				{NewAABB(-2, 41, 3, 3), "[1]", "[]"}, // a region over the edge wraps too
+				{NewAABB(195, 41, 2, 2), "[1]", "[]"},
+			} {
+				want := tc.want
+				if !world.Wrap {
+					want = tc.flat
+				}
+				if got := sorted(bp.Query(tc.region)); got != want {
+					t.Errorf("%s: query %v got %s, want %s", name, tc.region, got, want)
+				}
+			}
+			wantPairs := "[[1 3]]"
+			if !world.Wrap {
+				wantPairs = "[]"
+			}
+			if got := fmt.Sprint(pairSorted(bp.Pairs())); got != wantPairs {
+				t.Errorf("%s: pairs %s", name, got)
+			}
+			if id, ok := bp.Nearest(pt(2, 2), 5); ok != world.Wrap || ok && id != 2 {
+				t.Errorf("%s: nearest the top left %v, %v", name, id, ok)
+			}
+
+			// moving 1 off the edge leaves nothing of it behind on the far side
+			bp.Update(1, NewAABB(40, 40, 5, 5))
+			if got := sorted(bp.Query(NewAABB(0, 40, 10, 10))); got != "[3]" {
+				t.Errorf("%s: after moving got %s", name, got)
+			}
+			if got := sorted(bp.Query(NewAABB(90, 40, 10, 10))); got != "[]" {
+				t.Errorf("%s: after moving got %s", name, got)
+			}
+			bp.Remove(2)
+			for _, r := range []AABB{NewAABB(0, 0, 5, 5), NewAABB(95, 0, 5, 5), NewAABB(0, 95, 5, 5), NewAABB(95, 95, 5, 5)} {
+				if got := bp.Query(r); len(got) != 0 {
+					t.Errorf("%s: corner %v after removing got %v", name, r, got)
+				}
+			}
+			if bp.Len() != 3 {
+				t.Errorf("%s: %d left", name, bp.Len())
+			}
+		}
+	}
+}
+
+// TestBroadPhaseRandom - queries and pairs match testing every box against every other
+func TestBroadPhaseRandom(t *testing.T) {
+	for _, wrap := range []bool{false, true} {
+		world := World{Bounds: NewAABB(0, 0, 160, 80), Wrap: wrap}
+		for name, bp := range broadPhases(world) {
+			rng := rand.New(rand.NewSource(7))
+			box := func() AABB {
+				return NewAABB(rng.Float64()*180-10, rng.Float64()*100-10, rng.Float64()*20, rng.Float64()*20)
+			}
+			boxes := map[int]AABB{}
+			for i := 0; i < 200; i++ {
+				boxes[i] = box()
+				bp.Insert(i, boxes[i])
+			}
+			for i := 0; i < 80; i++ {
+				boxes[i] = box()
+				bp.Update(i, boxes[i])
+			}
+			for i := 150; i < 200; i++ {
+				delete(boxes, i)
+				bp.Remove(i)
+			}
+			if bp.Len() != len(boxes) {
+				t.Fatalf("%s, wrap %v: %d in, want %d", name, wrap, bp.Len(), len(boxes))
+			}
+			for n := 0; n < 50; n++ {
+				region := box()
+				var want []int
+				for id, b := range boxes {
+					if world.overlaps(region, b) {
+						want = append(want, id)
+					}
+				}
+				if got := sorted(bp.Query(region)); got != sorted(want) {
+					t.Fatalf("%s, wrap %v: query %v got %s, want %s", name, wrap, region, got, sorted(want))
+				}
+			}
+			for n := 0; n < 50; n++ {
+				p := pt(rng.Float64()*160, rng.Float64()*80)
+				best := math.Inf(1)
+				for _, b := range boxes {
+					best = math.Min(best, world.dist(p, b))
+				}
+				id, ok := bp.Nearest(p, 15)
+				if ok != (best <= 15) || ok && world.dist(p, boxes[id]) != best {
+					t.Fatalf("%s, wrap %v: nearest %v got %v, %v, want %v away", name, wrap, p, id, ok, best)
+				}
+			}
+			var want [][2]int
+			for a, ba := range boxes {
+				for b, bb := range boxes {
+					if a < b && world.overlaps(ba, bb) {
+						want = append(want, [2]int{a, b})
+					}
+				}
+			}
+			if got, exp := fmt.Sprint(pairSorted(bp.Pairs())), fmt.Sprint(pairSorted(want)); got != exp {
+				t.Errorf("%s, wrap %v: pairs differ", name, wrap)
+			}
+		}
+	}
+}
+
+// pairSorted - pairs with the lower id first, in order
+func pairSorted(pairs [][2]int) [][2]int {
+	out := make([][2]int, len(pairs))
+	for i, p := range pairs {
+		if p[0] > p[1] {
+			p[0], p[1] = p[1], p[0]
+		}
+		out[i] = p
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i][0] != out[j][0] {
+			return out[i][0] < out[j][0]
+		}
+		return out[i][1] < out[j][1]
+	})
+	return out
+}
@@ -0,0 +1,183 @@
+package collision
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kevincolyer/GameEngine/GameEngine"
+)
+
+// approx - a and b are the same give or take rounding
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+// pt - shorthand for a point
+func pt(x, y float64) GameEngine.P2D {
+	return GameEngine.P2D{X: x, Y: y}
+}
+
+// dir - shorthand for a vector
+func dir(dx, dy float64) GameEngine.V2D {
+	return GameEngine.V2D{Dx: dx, Dy: dy}
+}
+
+func TestOverlapShapes(t *testing.T) {
+	box := NewAABB(0, 0, 10, 10)
+	r := math.Sqrt2 / 2
+	for _, tc := range []struct {
+		name   string
+		a, b   Shape
+		ok     bool
+		normal GameEngine.V2D // zero to not check
+		depth  float64
+	}{
+		{"circles overlapping", Circle{pt(0, 0), 2}, Circle{pt(3, 0), 2}, true, dir(1, 0), 1},
+		{"circles touching", Circle{pt(0, 0), 2}, Circle{pt(4, 0), 2}, false, dir(0, 0), 0},
+		{"circles apart", Circle{pt(0, 0), 2}, Circle{pt(0, 9), 2}, false, dir(0, 0), 0},
+		{"circle in circle", Circle{pt(0, 0), 5}, Circle{pt(1, 0), 1}, true, dir(1, 0), 5},
+		{"boxes overlapping", box, NewAABB(8, 2, 5, 5), true, dir(1, 0), 2},
+		{"boxes touching", box, NewAABB(10, 0, 5, 5), false, dir(0, 0), 0},
+		{"boxes touching corners", box, NewAABB(10, 10, 5, 5), false, dir(0, 0), 0},
+		{"boxes apart", box, NewAABB(0, 11, 5, 5), false, dir(0, 0), 0},
+		{"box in box", box, NewAABB(4, 3, 2, 2), true, dir(0, 0), 5},
+		{"circle into box", Circle{pt(12, 5), 3}, box, true, dir(-1, 0), 1},
+		{"box into circle", box, Circle{pt(12, 5), 3}, true, dir(1, 0), 1},
+		{"circle touching box", Circle{pt(13, 5), 3}, box, false, dir(0, 0), 0},
+		{"circle by a corner", Circle{pt(12, 12), 2}, box, false, dir(0, 0), 0},
+		{"circle on a corner", Circle{pt(11, 11), 2}, box, true, dir(-r, -r), 2 - math.Sqrt2},
+		{"circle in box", Circle{pt(5, 2), 1}, box, true, dir(0, 1), 3},
+		{"turned box", OBB{C: pt(0, 0), HalfW: 1, HalfH: 1, Angle: GameEngine.PI / 4}, NewAABB(1.3, -1, 2, 2), true,
+			dir(1, 0), math.Sqrt2 - 1.3},
+		{"triangles overlapping", Polygon{[]GameEngine.P2D{pt(0, 0), pt(4, 0), pt(0, 4)}},
+			Polygon{[]GameEngine.P2D{pt(1, 1), pt(5, 1), pt(5, 5)}}, true, dir(r, r), 2 * r},
+		{"triangles touching", Polygon{[]GameEngine.P2D{pt(0, 0), pt(4, 0), pt(0, 4)}},
+			Polygon{[]GameEngine.P2D{pt(4, 0), pt(4, 4), pt(0, 4)}}, false, dir(0, 0), 0},
+		{"segment through box", Segment{pt(-5, 5), pt(5, 5)}, box, true, dir(0, 0), 5},
+		{"segment along an edge", Segment{pt(10, -5), pt(10, 15)}, box, false, dir(0, 0), 0},
+		{"segment apart", Segment{pt(11, 0), pt(20, 20)}, box, false, dir(0, 0), 0},
+		{"segment through circle", Segment{pt(-5, 0), pt(5, 0)}, Circle{pt(0, 1), 2}, true, dir(0, 1), 1},
+		{"segment by circle", Segment{pt(-5, 0), pt(5, 0)}, Circle{pt(0, 3), 2}, false, dir(0, 0), 0},
+	} {
+		c, ok := Overlap(tc.a, tc.b)
+		if ok != tc.ok || Overlaps(tc.a, tc.b) != tc.ok {
+			t.Errorf("%s: got %v, want %v", tc.name, ok, tc.ok)
+			continue
+		}
+		if !ok {
+			if c != (Contact{}) {
+				t.Errorf("%s: contact %+v without an overlap", tc.name, c)
+			}
+			continue
+		}
+		if !approx(c.Depth, tc.depth) {
+			t.Errorf("%s: depth %v, want %v", tc.name, c.Depth, tc.depth)
+		}
+		if tc.normal != (GameEngine.V2D{}) && (!approx(c.Normal.Dx, tc.normal.Dx) || !approx(c.Normal.Dy, tc.normal.Dy)) {
+			t.Errorf("%s: normal %v, want %v", tc.name, c.Normal, tc.normal)
+		}
+		// pushing b out along the normal by the depth separates them
+		if _, still := Overlap(tc.a, moved(tc.b, c.Normal.Scale(c.Depth+1e-6))); still {
+			t.Errorf("%s: still overlapping once pushed apart", tc.name)
+		}
+	}
+}
+
+// moved - s moved by d
+func moved(s Shape, d GameEngine.V2D) Shape {
+	switch sh := s.(type) {
+	case Circle:
+		sh.C = sh.C.Add(d)
+		return sh
+	case Segment:
+		return Segment{sh.A.Add(d), sh.B.Add(d)}
+	}
+	var ps []GameEngine.P2D
+	for _, p := range s.verts() {
+		ps = append(ps, p.Add(d))
+	}
+	return Polygon{ps}
+}
+
+func TestHullConcave(t *testing.T) {
+	// a square with a notch cut into the top, a point inside, one on an edge and a corner given twice
+	in := []GameEngine.P2D{pt(0, 0), pt(5, 0), pt(10, 0), pt(10, 10), pt(6, 10), pt(5, 6), pt(4, 10), pt(0, 10),
+		pt(3, 3), pt(10, 10)}
+	h := Hull(in)
+	if len(h.Points) != 4 {
+		t.Fatalf("hull %v", h.Points)
+	}
+	corners := map[GameEngine.P2D]bool{pt(0, 0): true, pt(10, 0): true, pt(10, 10): true, pt(0, 10): true}
+	for _, p := range h.Points {
+		if !corners[p] {
+			t.Errorf("%v isn't a corner", p)
+		}
+		delete(corners, p)
+	}
+	// the notch is filled in
+	if !Contains(h, pt(5, 8)) {
+		t.Errorf("notch not in the hull")
+	}
+	if c, ok := Overlap(h, NewAABB(4.5, 7, 1, 1)); !ok || !approx(c.Depth, 3) {
+		t.Errorf("box in the notch: %+v, %v", c, ok)
+	}
+	if Overlaps(h, NewAABB(11, 0, 1, 1)) {
+		t.Errorf("box outside overlaps")
+	}
+	// too few points to turn are passed back
+	if h := Hull([]GameEngine.P2D{pt(1, 2), pt(3, 4)}); len(h.Points) != 2 {
+		t.Errorf("two points: %v", h.Points)
+	}
+}
+
+func TestRayCast(t *testing.T) {
+	box := NewAABB(0, 0, 10, 10)
+	r := math.Sqrt2 / 2
+	for _, tc := range []struct {
+		name     string
+		ray      Ray
+		s        Shape
+		max      float64
+		ok       bool
+		fraction float64
+		normal   GameEngine.V2D
+	}{
+		{"box from the left", Ray{pt(-10, 5), dir(1, 0)}, box, 100, true, 10, dir(-1, 0)},
+		{"box in Dir lengths", Ray{pt(-10, 5), dir(2, 0)}, box, 100, true, 5, dir(-1, 0)},
+		{"box from above", Ray{pt(5, -3), dir(0, 1)}, box, 100, true, 3, dir(0, -1)},
+		{"box from below right", Ray{pt(12, 20), dir(-1, -2)}, box, 100, true, 5, dir(0, 1)},
+		{"box just in reach", Ray{pt(-10, 5), dir(1, 0)}, box, 10, true, 10, dir(-1, 0)},
+		{"box out of reach", Ray{pt(-10, 5), dir(1, 0)}, box, 9.9, false, 0, dir(0, 0)},
+		{"box missed", Ray{pt(-10, 15), dir(1, 0)}, box, 100, false, 0, dir(0, 0)},
+		{"box behind", Ray{pt(-10, 5), dir(-1, 0)}, box, 100, false, 0, dir(0, 0)},
+		{"inside box", Ray{pt(5, 5), dir(1, 0)}, box, 100, false, 0, dir(0, 0)},
+		{"circle head on", Ray{pt(-10, 0), dir(1, 0)}, Circle{pt(0, 0), 2}, 100, true, 8, dir(-1, 0)},
+		{"circle at an angle", Ray{pt(-10, -10), dir(r, r)}, Circle{pt(0, 0), 2}, 100, true, 10*math.Sqrt2 - 2,
+			dir(-r, -r)},
+		{"circle glanced", Ray{pt(-10, 2), dir(1, 0)}, Circle{pt(0, 0), 2}, 100, true, 10, dir(0, 1)},
+		{"circle missed", Ray{pt(-10, 2.1), dir(1, 0)}, Circle{pt(0, 0), 2}, 100, false, 0, dir(0, 0)},
+		{"inside circle", Ray{pt(0, 1), dir(1, 0)}, Circle{pt(0, 0), 2}, 100, false, 0, dir(0, 0)},
+		{"segment", Ray{pt(0, 0), dir(1, 0)}, Segment{pt(5, -5), pt(5, 5)}, 100, true, 5, dir(-1, 0)},
+		{"segment other side", Ray{pt(10, 0), dir(-1, 0)}, Segment{pt(5, -5), pt(5, 5)}, 100, true, 5, dir(1, 0)},
+		{"segment end", Ray{pt(0, 5), dir(1, 0)}, Segment{pt(5, -5), pt(5, 5)}, 100, true, 5, dir(-1, 0)},
+		{"past segment end", Ray{pt(0, 6), dir(1, 0)}, Segment{pt(5, -5), pt(5, 5)}, 100, false, 0, dir(0, 0)},
+		{"along segment", Ray{pt(0, 0), dir(0, 1)}, Segment{pt(0, 5), pt(0, 9)}, 100, false, 0, dir(0, 0)},
+		{"triangle slope", Ray{pt(5, 5), dir(-1, -1)}, Polygon{[]GameEngine.P2D{pt(0, 0), pt(4, 0), pt(0, 4)}}, 100,
+			true, 3, dir(r, r)},
+	} {
+		h, ok := RayCast(tc.ray, tc.s, tc.max)
+		if ok != tc.ok {
+			t.Errorf("%s: got %v, want %v", tc.name, ok, tc.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if !approx(h.Fraction, tc.fraction) || !approx(h.Normal.Dx, tc.normal.Dx) || !approx(h.Normal.Dy, tc.normal.Dy) {
+			t.Errorf("%s: got %v along, normal %v, want %v, %v", tc.name, h.Fraction, h.Normal, tc.fraction, tc.normal)
+		}
+		if at := tc.ray.At(tc.fraction); !approx(h.Point.X, at.X) || !approx(h.Point.Y, at.Y) {
+			t.Errorf("%s: point %v, want %v", tc.name, h.Point, at)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package collision
+
+import (
+	"math"
+
+	"github.com/kevincolyer/GameEngine/GameEngine"
+)
+
+// Grid - spatial hash grid broad phase. Space is cut into square cells and each thing is listed in every cell
+// its box touches. Best when things are all about the same size as a cell
+type Grid[T comparable] struct {
+	World    World
+	CellSize float64
+	cells    map[[2]int][]T
+	boxes    map[T]AABB
+}
+
+// NewGrid - builds a grid with cells of size cellSize. world only matters if it wraps
+func NewGrid[T comparable](cellSize float64, world World) *Grid[T] {
+	return &Grid[T]{
+		World:    world,
+		CellSize: cellSize,
+		cells:    map[[2]int][]T{},
+		boxes:    map[T]AABB{},
+	}
+}
+
+// Insert - for BroadPhase
+func (g *Grid[T]) Insert(id T, box AABB) {
+	g.Update(id, box)
+}
+
+// Update - for BroadPhase
+func (g *Grid[T]) Update(id T, box AABB) {
+	if _, ok := g.boxes[id]; ok {
+		g.Remove(id)
+	}
+	g.boxes[id] = box
+	g.eachCell(box, func(k [2]int) {
+		g.cells[k] = append(g.cells[k], id)
+	})
+}
+
+// Remove - for BroadPhase
+func (g *Grid[T]) Remove(id T) {
+	box, ok := g.boxes[id]
+	if !ok {
+		return
+	}
+	delete(g.boxes, id)
+	g.eachCell(box, func(k [2]int) {
+		ids := g.cells[k]
+		for i, o := range ids {
+			if o == id {
+				ids[i] = ids[len(ids)-1]
+				ids = ids[:len(ids)-1]
+				break
+			}
+		}
+		if len(ids) == 0 {
+			delete(g.cells, k)
+		} else {
+			g.cells[k] = ids
+		}
+	})
+}
+
+// Len - for BroadPhase
+func (g *Grid[T]) Len() int {
+	return len(g.boxes)
+}
+
+// Box - bounding box id was last given and true, or false if it isn't in the grid
+func (g *Grid[T]) Box(id T) (AABB, bool) {
+	box, ok := g.boxes[id]
+	return box, ok
+}
+
+// Query - for BroadPhase
+func (g *Grid[T]) Query(region AABB) []T {
+	var out []T
+	x0, y0 := g.cellOf(region.Min)
+	x1, y1 := g.cellOf(region.Max)
+	if float64(x1-x0+1)*float64(y1-y0+1) > float64(len(g.boxes)) {
+		// quicker to just look at everything
+		for id, box := range g.boxes {
+			if g.World.overlaps(region, box) {
+				out = append(out, id)
+			}
+		}
+		return out
+	}
+	seen := map[T]bool{}
+	g.eachCell(region, func(k [2]int) {
+		for _, id := range g.cells[k] {
+			if !seen[id] && g.World.overlaps(region, g.boxes[id]) {
+				seen[id] = true
+				out = append(out, id)
+			}
+		}
+	})
+	return out
+}
+
+// Nearest - for BroadPhase
+func (g *Grid[T]) Nearest(p GameEngine.P2D, maxDist float64) (T, bool) {
+	return nearest(g.World, len(g.boxes), p, maxDist, g.CellSize, g.Query, func(id T) AABB { return g.boxes[id] })
+}
+
+// Pairs - for BroadPhase
+func (g *Grid[T]) Pairs() [][2]T {
+	var set pairSet[T]
+	for _, ids := range g.cells {
+		for i := range ids {
+			for j := i + 1; j < len(ids); j++ {
+				if g.World.overlaps(g.boxes[ids[i]], g.boxes[ids[j]]) {
+					set.add(ids[i], ids[j])
+				}
+			}
+		}
+	}
+	return set.pairs
+}
+
+// eachCell - calls fn with the key of every cell box touches
+func (g *Grid[T]) eachCell(box AABB, fn func(k [2]int)) {
+	seen := map[[2]int]bool{}
+	for _, b := range g.World.pieces(box) {
+		x0, y0 := g.cellOf(b.Min)
+		x1, y1 := g.cellOf(b.Max)
+		for x := x0; x <= x1; x++ {
+			for y := y0; y <= y1; y++ {
+				k := [2]int{x, y}
+				if !seen[k] {
+					seen[k] = true
+					fn(k)
+				}
+			}
+		}
+	}
+}
+
+// cellOf - cell holding point p
+func (g *Grid[T]) cellOf(p GameEngine.P2D) (x, y int) {
+	return int(math.Floor(p.X / g.CellSize)), int(math.Floor(p.Y / g.CellSize))
+}
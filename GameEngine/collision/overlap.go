@@ -0,0 +1,208 @@
+package collision
+
+import (
+	"math"
+
+	"github.com/kevincolyer/GameEngine/GameEngine"
+)
+
+// Contact - how two overlapping shapes touch
+type Contact struct {
+	Normal GameEngine.V2D // unit vector from the first shape towards the second
+	Depth  float64        // how far they overlap along Normal. Move the second shape Normal*Depth to separate
+	Point  GameEngine.P2D // roughly where they touch
+}
+
+// Overlap - tests if shapes a and b overlap. Returns the contact and true if they do
+func Overlap(a, b Shape) (Contact, bool) {
+	ca, aCircle := a.(Circle)
+	cb, bCircle := b.(Circle)
+	switch {
+	case aCircle && bCircle:
+		return circleCircle(ca, cb)
+	case aCircle:
+		return circlePolygon(ca, b.verts())
+	case bCircle:
+		c, ok := circlePolygon(cb, a.verts())
+		return flip(c), ok
+	}
+	return polygonPolygon(a.verts(), b.verts())
+}
+
+// Overlaps - true/false version of Overlap
+func Overlaps(a, b Shape) bool {
+	if !a.Bounds().Overlaps(b.Bounds()) {
+		return false
+	}
+	_, ok := Overlap(a, b)
+	return ok
+}
+
+// Contains - true if point p is inside shape s. Segments contain nothing
+func Contains(s Shape, p GameEngine.P2D) bool {
+	if c, ok := s.(Circle); ok {
+		return c.C.Dist(p) < c.R
+	}
+	return polygonContains(s.verts(), p)
+}
+
+func flip(c Contact) Contact {
+	c.Normal = c.Normal.Scale(-1)
+	return c
+}
+
+func circleCircle(a, b Circle) (c Contact, ok bool) {
+	d := b.C.Sub(a.C)
+	dist := d.Len()
+	if dist >= a.R+b.R {
+		return
+	}
+	c.Normal = GameEngine.V2D{Dx: 1}
+	if dist > 0 {
+		c.Normal = d.Scale(1 / dist)
+	}
+	c.Depth = a.R + b.R - dist
+	c.Point = a.C.Add(c.Normal.Scale(a.R - c.Depth/2))
+	return c, true
+}
+
+// circlePolygon - SAT with the polygon's edge normals and the axis from the circle to its nearest corner
+func circlePolygon(circ Circle, poly []GameEngine.P2D) (c Contact, ok bool) {
+	if len(poly) == 0 {
+		return
+	}
+	axes := edgeNormals(poly)
+	nearest := poly[0]
+	for _, p := range poly[1:] {
+		if p.Dist(circ.C) < nearest.Dist(circ.C) {
+			nearest = p
+		}
+	}
+	if toCorner := nearest.Sub(circ.C); toCorner.Len() > 0 {
+		axes = append(axes, toCorner.Normalise())
+	}
+	c.Depth = math.Inf(1)
+	for _, n := range axes {
+		cmid := dotP(circ.C, n)
+		pmin, pmax := project(poly, n)
+		forward, back := cmid+circ.R-pmin, pmax-(cmid-circ.R)
+		if forward <= 0 || back <= 0 {
+			return Contact{}, false
+		}
+		if forward < c.Depth {
+			c.Depth, c.Normal = forward, n
+		}
+		if back < c.Depth {
+			c.Depth, c.Normal = back, n.Scale(-1)
+		}
+	}
+	// deepest point of the circle into the polygon
+	c.Point = circ.C.Add(c.Normal.Scale(circ.R - c.Depth/2))
+	return c, true
+}
+
+// polygonPolygon - SAT using the edge normals of both polygons
+func polygonPolygon(a, b []GameEngine.P2D) (c Contact, ok bool) {
+	if len(a) == 0 || len(b) == 0 {
+		return
+	}
+	c.Depth = math.Inf(1)
+	for _, n := range append(edgeNormals(a), edgeNormals(b)...) {
+		amin, amax := project(a, n)
+		bmin, bmax := project(b, n)
+		// distance to push b apart from a forward or back along n
+		forward, back := amax-bmin, bmax-amin
+		if forward <= 0 || back <= 0 {
+			return Contact{}, false
+		}
+		if forward < c.Depth {
+			c.Depth, c.Normal = forward, n
+		}
+		if back < c.Depth {
+			c.Depth, c.Normal = back, n.Scale(-1)
+		}
+	}
+	c.Point = contactPoint(a, b, c.Normal)
+	return c, true
+}
+
+// contactPoint - average of the corners of each polygon inside the other. If none are (edges crossing) the
+// middle of the two deepest corners
+func contactPoint(a, b []GameEngine.P2D, n GameEngine.V2D) GameEngine.P2D {
+	var inside []GameEngine.P2D
+	for _, p := range b {
+		if polygonContains(a, p) {
+			inside = append(inside, p)
+		}
+	}
+	for _, p := range a {
+		if polygonContains(b, p) {
+			inside = append(inside, p)
+		}
+	}
+	if len(inside) > 0 {
+		return centreOf(inside)
+	}
+	sa := support(a, n)
+	sb := support(b, n.Scale(-1))
+	return GameEngine.P2D{X: (sa.X + sb.X) / 2, Y: (sa.Y + sb.Y) / 2}
+}
+
+// polygonContains - true if p is inside convex polygon ps (either winding)
+func polygonContains(ps []GameEngine.P2D, p GameEngine.P2D) bool {
+	if len(ps) < 3 {
+		return false
+	}
+	sign := 0.0
+	for i := range ps {
+		a, b := ps[i], ps[(i+1)%len(ps)]
+		s := b.Sub(a).Cross(p.Sub(a))
+		if s == 0 {
+			continue
+		}
+		if sign == 0 {
+			sign = GameEngine.Sign(s)
+		} else if GameEngine.Sign(s) != sign {
+			return false
+		}
+	}
+	return sign != 0
+}
+
+// edgeNormals - unit normals of each edge (direction unimportant for SAT)
+func edgeNormals(ps []GameEngine.P2D) []GameEngine.V2D {
+	n := make([]GameEngine.V2D, 0, len(ps))
+	for i := range ps {
+		e := ps[(i+1)%len(ps)].Sub(ps[i])
+		if e.Len() > 0 {
+			n = append(n, e.Perp().Normalise())
+		}
+	}
+	return n
+}
+
+// project - min and max of points along axis n
+func project(ps []GameEngine.P2D, n GameEngine.V2D) (min, max float64) {
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, p := range ps {
+		d := dotP(p, n)
+		min = math.Min(min, d)
+		max = math.Max(max, d)
+	}
+	return
+}
+
+// support - the point furthest along n
+func support(ps []GameEngine.P2D, n GameEngine.V2D) GameEngine.P2D {
+	best := ps[0]
+	for _, p := range ps[1:] {
+		if dotP(p, n) > dotP(best, n) {
+			best = p
+		}
+	}
+	return best
+}
+
+func dotP(p GameEngine.P2D, n GameEngine.V2D) float64 {
+	return p.X*n.Dx + p.Y*n.Dy
+}
@@ -0,0 +1,228 @@
+package collision
+
+import "github.com/kevincolyer/GameEngine/GameEngine"
+
+// QuadTree - dynamic quadtree broad phase over a fixed world area. Nodes split into four when they get
+// too full; things too big for a child stay in the parent. Things outside the world are kept at the root.
+// Copes better than a Grid with things of very different sizes
+type QuadTree[T comparable] struct {
+	World    World
+	MaxItems int // things a node holds before it splits
+	MaxDepth int
+	root     *quadNode[T]
+	boxes    map[T]AABB
+}
+
+type quadItem[T comparable] struct {
+	id  T
+	box AABB // the piece of the thing's box stored here
+}
+
+type quadNode[T comparable] struct {
+	bounds   AABB
+	depth    int
+	items    []quadItem[T]
+	children *[4]*quadNode[T]
+}
+
+// NewQuadTree - builds an empty quadtree covering world
+func NewQuadTree[T comparable](world World) *QuadTree[T] {
+	return &QuadTree[T]{
+		World:    world,
+		MaxItems: 8,
+		MaxDepth: 8,
+		root:     &quadNode[T]{bounds: world.Bounds},
+		boxes:    map[T]AABB{},
+	}
+}
+
+// Insert - for BroadPhase
+func (q *QuadTree[T]) Insert(id T, box AABB) {
+	q.Update(id, box)
+}
+
+// Update - for BroadPhase
+func (q *QuadTree[T]) Update(id T, box AABB) {
+	if _, ok := q.boxes[id]; ok {
+		q.Remove(id)
+	}
+	q.boxes[id] = box
+	for _, b := range q.World.pieces(box) {
+		q.insert(q.root, quadItem[T]{id: id, box: b})
+	}
+}
+
+// Remove - for BroadPhase
+func (q *QuadTree[T]) Remove(id T) {
+	box, ok := q.boxes[id]
+	if !ok {
+		return
+	}
+	delete(q.boxes, id)
+	for _, b := range q.World.pieces(box) {
+		q.remove(q.root, quadItem[T]{id: id, box: b})
+	}
+}
+
+// Len - for BroadPhase
+func (q *QuadTree[T]) Len() int {
+	return len(q.boxes)
+}
+
+// Box - bounding box id was last given and true, or false if it isn't in the tree
+func (q *QuadTree[T]) Box(id T) (AABB, bool) {
+	box, ok := q.boxes[id]
+	return box, ok
+}
+
+// Query - for BroadPhase
+func (q *QuadTree[T]) Query(region AABB) []T {
+	var out []T
+	seen := map[T]bool{}
+	for _, r := range q.World.pieces(region) {
+		q.root.visit(r, func(it quadItem[T]) {
+			if !seen[it.id] && it.box.Overlaps(r) {
+				seen[it.id] = true
+				out = append(out, it.id)
+			}
+		})
+	}
+	return out
+}
+
+// Nearest - for BroadPhase
+func (q *QuadTree[T]) Nearest(p GameEngine.P2D, maxDist float64) (T, bool) {
+	start := q.World.Bounds.W() / 16
+	return nearest(q.World, len(q.boxes), p, maxDist, start, q.Query, func(id T) AABB { return q.boxes[id] })
+}
+
+// Pairs - for BroadPhase
+func (q *QuadTree[T]) Pairs() [][2]T {
+	var set pairSet[T]
+	q.root.pairs(nil, &set)
+	return set.pairs
+}
+
+// insert - puts item in the deepest node that wholly holds it, splitting full nodes
+func (q *QuadTree[T]) insert(n *quadNode[T], it quadItem[T]) {
+	for {
+		if n.children == nil {
+			n.items = append(n.items, it)
+			if len(n.items) > q.MaxItems && n.depth < q.MaxDepth {
+				n.split()
+			}
+			return
+		}
+		child := n.childFor(it.box)
+		if child == nil {
+			n.items = append(n.items, it)
+			return
+		}
+		n = child
+	}
+}
+
+// remove - finds item by following the same path insert would and takes it out
+func (q *QuadTree[T]) remove(n *quadNode[T], it quadItem[T]) {
+	var path []*quadNode[T]
+	for n != nil {
+		path = append(path, n)
+		for i, o := range n.items {
+			if o.id == it.id && o.box == it.box {
+				n.items = append(n.items[:i], n.items[i+1:]...)
+				// tidy up nodes whose children have all emptied
+				for j := len(path) - 1; j >= 0; j-- {
+					path[j].collapse()
+				}
+				return
+			}
+		}
+		if n.children == nil {
+			return
+		}
+		n = n.childFor(it.box)
+	}
+}
+
+// split - makes four children and moves down any items that fit in one
+func (n *quadNode[T]) split() {
+	mid := n.bounds.Centre()
+	b := n.bounds
+	n.children = &[4]*quadNode[T]{
+		{bounds: AABB{Min: b.Min, Max: mid}, depth: n.depth + 1},
+		{bounds: AABB{Min: GameEngine.P2D{X: mid.X, Y: b.Min.Y}, Max: GameEngine.P2D{X: b.Max.X, Y: mid.Y}}, depth: n.depth + 1},
+		{bounds: AABB{Min: GameEngine.P2D{X: b.Min.X, Y: mid.Y}, Max: GameEngine.P2D{X: mid.X, Y: b.Max.Y}}, depth: n.depth + 1},
+		{bounds: AABB{Min: mid, Max: b.Max}, depth: n.depth + 1},
+	}
+	items := n.items
+	n.items = nil
+	for _, it := range items {
+		if child := n.childFor(it.box); child != nil {
+			child.items = append(child.items, it)
+		} else {
+			n.items = append(n.items, it)
+		}
+	}
+}
+
+// collapse - drops the children if they are all empty leaves
+func (n *quadNode[T]) collapse() {
+	if n.children == nil {
+		return
+	}
+	for _, c := range n.children {
+		if c.children != nil || len(c.items) > 0 {
+			return
+		}
+	}
+	n.children = nil
+}
+
+// childFor - the child that wholly holds box, or nil if none does
+func (n *quadNode[T]) childFor(box AABB) *quadNode[T] {
+	for _, c := range n.children {
+		if box.Min.X >= c.bounds.Min.X && box.Max.X <= c.bounds.Max.X &&
+			box.Min.Y >= c.bounds.Min.Y && box.Max.Y <= c.bounds.Max.Y {
+			return c
+		}
+	}
+	return nil
+}
+
+// visit - calls fn for every item in nodes that overlap region
+func (n *quadNode[T]) visit(region AABB, fn func(it quadItem[T])) {
+	for _, it := range n.items {
+		fn(it)
+	}
+	if n.children == nil {
+		return
+	}
+	for _, c := range n.children {
+		if c.bounds.Overlaps(region) {
+			c.visit(region, fn)
+		}
+	}
+}
+
+// pairs - pairs items here with each other and with the items of every ancestor
+func (n *quadNode[T]) pairs(ancestors []quadItem[T], set *pairSet[T]) {
+	for i, a := range n.items {
+		for _, b := range n.items[i+1:] {
+			if a.box.Overlaps(b.box) {
+				set.add(a.id, b.id)
+			}
+		}
+		for _, b := range ancestors {
+			if a.box.Overlaps(b.box) {
+				set.add(a.id, b.id)
+			}
+		}
+	}
+	if n.children == nil {
+		return
+	}
+	ancestors = append(ancestors, n.items...)
+	for _, c := range n.children {
+		c.pairs(ancestors[:len(ancestors):len(ancestors)], set)
+	}
+}
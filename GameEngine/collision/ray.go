@@ -0,0 +1,132 @@
+package collision
+
+import (
+	"math"
+
+	"github.com/kevincolyer/GameEngine/GameEngine"
+)
+
+// Ray - starts at Origin and runs along Dir. Fractions are measured in lengths of Dir
+type Ray struct {
+	Origin GameEngine.P2D
+	Dir    GameEngine.V2D
+}
+
+// RayHit - where a ray hit a shape
+type RayHit struct {
+	Point    GameEngine.P2D
+	Normal   GameEngine.V2D // unit surface normal at Point, facing back along the ray
+	Fraction float64        // Point is Origin + Dir*Fraction
+}
+
+// At - point a fraction of the way along the ray
+func (r Ray) At(fraction float64) GameEngine.P2D {
+	return r.Origin.Add(r.Dir.Scale(fraction))
+}
+
+// RayCast - casts r at shape s. Returns the first hit no further than maxFraction along the ray and true, or
+// false if it misses. Rays starting inside a shape don't hit it
+func RayCast(r Ray, s Shape, maxFraction float64) (h RayHit, ok bool) {
+	switch sh := s.(type) {
+	case Circle:
+		h, ok = rayCircle(r, sh)
+	case Segment:
+		h, ok = raySegment(r, sh)
+	default:
+		h, ok = rayPolygon(r, s.verts())
+	}
+	if !ok || h.Fraction > maxFraction {
+		return RayHit{}, false
+	}
+	return
+}
+
+func rayCircle(r Ray, c Circle) (h RayHit, ok bool) {
+	// solve |o + d*t - c|^2 = r^2 for the smallest t
+	m := r.Origin.Sub(c.C)
+	a := r.Dir.Dot(r.Dir)
+	b := m.Dot(r.Dir)
+	cc := m.Dot(m) - c.R*c.R
+	if a == 0 || cc < 0 {
+		return
+	}
+	disc := b*b - a*cc
+	if disc < 0 {
+		return
+	}
+	t := (-b - math.Sqrt(disc)) / a
+	if t < 0 {
+		return
+	}
+	h.Fraction = t
+	h.Point = r.At(t)
+	h.Normal = h.Point.Sub(c.C).Normalise()
+	return h, true
+}
+
+func raySegment(r Ray, s Segment) (h RayHit, ok bool) {
+	e := s.B.Sub(s.A)
+	denom := r.Dir.Cross(e)
+	if denom == 0 {
+		// parallel
+		return
+	}
+	ao := s.A.Sub(r.Origin)
+	t := ao.Cross(e) / denom
+	u := ao.Cross(r.Dir) / denom
+	if t < 0 || u < 0 || u > 1 {
+		return
+	}
+	h.Fraction = t
+	h.Point = r.At(t)
+	h.Normal = e.Perp().Normalise()
+	if h.Normal.Dot(r.Dir) > 0 {
+		h.Normal = h.Normal.Scale(-1)
+	}
+	return h, true
+}
+
+// rayPolygon - Cyrus-Beck clipping of the ray against each edge of a convex polygon
+func rayPolygon(r Ray, ps []GameEngine.P2D) (h RayHit, ok bool) {
+	if len(ps) < 3 {
+		return
+	}
+	centre := centreOf(ps)
+	enter, exit := 0.0, math.Inf(1)
+	var normal GameEngine.V2D
+	for i := range ps {
+		a, b := ps[i], ps[(i+1)%len(ps)]
+		n := b.Sub(a).Perp().Normalise()
+		if n.Dot(a.Sub(centre)) < 0 {
+			n = n.Scale(-1) // make it face out
+		}
+		// ray is outside this edge's half plane while dist > 0
+		dist := r.Origin.Sub(a).Dot(n)
+		rate := r.Dir.Dot(n)
+		if rate == 0 {
+			if dist > 0 {
+				return
+			}
+			continue
+		}
+		t := -dist / rate
+		if rate < 0 {
+			if t > enter || (t == enter && normal == GameEngine.V2D{}) {
+				enter, normal = t, n
+			}
+		} else if t < exit {
+			exit = t
+		}
+		if enter > exit {
+			return
+		}
+	}
+	if normal == (GameEngine.V2D{}) {
+		// started inside
+		return
+	}
+	h.Fraction = enter
+	h.Point = r.At(enter)
+	h.Normal = normal
+	return h, true
+}
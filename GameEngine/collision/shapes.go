@@ -0,0 +1,193 @@
+// Package collision - collision shapes for GameEngine with overlap tests that give a contact normal and
+// penetration depth, and ray casts. Polygons are tested with the separating axis theorem (SAT)
+package collision
+
+import (
+	"math"
+	"sort"
+
+	"github.com/kevincolyer/GameEngine/GameEngine"
+)
+
+// Shape - a collision shape in world coordinates
+type Shape interface {
+	// Bounds - smallest axis aligned box holding the shape
+	Bounds() AABB
+	// Centre - middle of the shape
+	Centre() GameEngine.P2D
+	// verts - corners of the shape, nil for a circle
+	verts() []GameEngine.P2D
+}
+
+// Circle - centre and radius
+type Circle struct {
+	C GameEngine.P2D
+	R float64
+}
+
+// AABB - axis aligned box from Min (top left) to Max (bottom right)
+type AABB struct {
+	Min, Max GameEngine.P2D
+}
+
+// OBB - box of half width and half height rotated by Angle about its centre C
+type OBB struct {
+	C            GameEngine.P2D
+	HalfW, HalfH float64
+	Angle        float64
+}
+
+// Polygon - convex polygon. Points can wind either way
+type Polygon struct {
+	Points []GameEngine.P2D
+}
+
+// Segment - straight line between A and B
+type Segment struct {
+	A, B GameEngine.P2D
+}
+
+// NewAABB - box with top left x, y and size w, h
+func NewAABB(x, y, w, h float64) AABB {
+	return AABB{Min: GameEngine.P2D{X: x, Y: y}, Max: GameEngine.P2D{X: x + w, Y: y + h}}
+}
+
+// Bounds - for Shape
+func (c Circle) Bounds() AABB {
+	return AABB{Min: GameEngine.P2D{X: c.C.X - c.R, Y: c.C.Y - c.R}, Max: GameEngine.P2D{X: c.C.X + c.R, Y: c.C.Y + c.R}}
+}
+
+// Centre - for Shape
+func (c Circle) Centre() GameEngine.P2D { return c.C }
+
+func (c Circle) verts() []GameEngine.P2D { return nil }
+
+// Bounds - for Shape
+func (b AABB) Bounds() AABB { return b }
+
+// Centre - for Shape
+func (b AABB) Centre() GameEngine.P2D {
+	return GameEngine.P2D{X: (b.Min.X + b.Max.X) / 2, Y: (b.Min.Y + b.Max.Y) / 2}
+}
+
+func (b AABB) verts() []GameEngine.P2D {
+	return []GameEngine.P2D{b.Min, {X: b.Max.X, Y: b.Min.Y}, b.Max, {X: b.Min.X, Y: b.Max.Y}}
+}
+
+// W - width of box
+func (b AABB) W() float64 { return b.Max.X - b.Min.X }
+
+// H - height of box
+func (b AABB) H() float64 { return b.Max.Y - b.Min.Y }
+
+// Overlaps - quick true/false test of two boxes
+func (b AABB) Overlaps(o AABB) bool {
+	return b.Min.X < o.Max.X && o.Min.X < b.Max.X && b.Min.Y < o.Max.Y && o.Min.Y < b.Max.Y
+}
+
+// ContainsPoint - true if p is inside the box
+func (b AABB) ContainsPoint(p GameEngine.P2D) bool {
+	return p.X >= b.Min.X && p.X < b.Max.X && p.Y >= b.Min.Y && p.Y < b.Max.Y
+}
+
+// Union - smallest box holding both boxes
+func (b AABB) Union(o AABB) AABB {
+	return AABB{
+		Min: GameEngine.P2D{X: math.Min(b.Min.X, o.Min.X), Y: math.Min(b.Min.Y, o.Min.Y)},
+		Max: GameEngine.P2D{X: math.Max(b.Max.X, o.Max.X), Y: math.Max(b.Max.Y, o.Max.Y)},
+	}
+}
+
+// Bounds - for Shape
+func (b OBB) Bounds() AABB { return boundsOf(b.verts()) }
+
+// Centre - for Shape
+func (b OBB) Centre() GameEngine.P2D { return b.C }
+
+func (b OBB) verts() []GameEngine.P2D {
+	ax := GameEngine.V2D{Dx: b.HalfW}.Rotate(b.Angle)
+	ay := GameEngine.V2D{Dy: b.HalfH}.Rotate(b.Angle)
+	return []GameEngine.P2D{
+		b.C.Add(ax.Scale(-1)).Add(ay.Scale(-1)),
+		b.C.Add(ax).Add(ay.Scale(-1)),
+		b.C.Add(ax).Add(ay),
+		b.C.Add(ax.Scale(-1)).Add(ay),
+	}
+}
+
+// Bounds - for Shape
+func (p Polygon) Bounds() AABB { return boundsOf(p.Points) }
+
+// Centre - for Shape. Average of the points
+func (p Polygon) Centre() GameEngine.P2D { return centreOf(p.Points) }
+
+func (p Polygon) verts() []GameEngine.P2D { return p.Points }
+
+// Bounds - for Shape
+func (s Segment) Bounds() AABB { return boundsOf([]GameEngine.P2D{s.A, s.B}) }
+
+// Centre - for Shape
+func (s Segment) Centre() GameEngine.P2D {
+	return GameEngine.P2D{X: (s.A.X + s.B.X) / 2, Y: (s.A.Y + s.B.Y) / 2}
+}
+
+func (s Segment) verts() []GameEngine.P2D { return []GameEngine.P2D{s.A, s.B} }
+
+// Hull - convex hull of points (monotone chain), for turning models like asteroids rocks into a Polygon
+func Hull(points []GameEngine.P2D) Polygon {
+	if len(points) < 3 {
+		return Polygon{Points: append([]GameEngine.P2D(nil), points...)}
+	}
+	ps := append([]GameEngine.P2D(nil), points...)
+	sort.Slice(ps, func(i, j int) bool {
+		if ps[i].X == ps[j].X {
+			return ps[i].Y < ps[j].Y
+		}
+		return ps[i].X < ps[j].X
+	})
+	hull := make([]GameEngine.P2D, 0, 2*len(ps))
+	turn := func(o, a, b GameEngine.P2D) float64 { return a.Sub(o).Cross(b.Sub(o)) }
+	// lower then upper chain
+	for _, p := range ps {
+		for len(hull) >= 2 && turn(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(ps) - 2; i >= 0; i-- {
+		for len(hull) >= lower && turn(hull[len(hull)-2], hull[len(hull)-1], ps[i]) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, ps[i])
+	}
+	return Polygon{Points: hull[:len(hull)-1]}
+}
+
+// boundsOf - box around points
+func boundsOf(ps []GameEngine.P2D) AABB {
+	if len(ps) == 0 {
+		return AABB{}
+	}
+	b := AABB{Min: ps[0], Max: ps[0]}
+	for _, p := range ps[1:] {
+		b.Min.X = math.Min(b.Min.X, p.X)
+		b.Min.Y = math.Min(b.Min.Y, p.Y)
+		b.Max.X = math.Max(b.Max.X, p.X)
+		b.Max.Y = math.Max(b.Max.Y, p.Y)
+	}
+	return b
+}
+
+// centreOf - average of points
+func centreOf(ps []GameEngine.P2D) (c GameEngine.P2D) {
+	for _, p := range ps {
+		c.X += p.X
+		c.Y += p.Y
+	}
+	if len(ps) > 0 {
+		c.X /= float64(len(ps))
+		c.Y /= float64(len(ps))
+	}
+	return
+}
@@ -0,0 +1,245 @@
+package GameEngine
+
+import "math"
+
+// toByte - v clamped to 0-255 and rounded
+func toByte(v float64) uint8 {
+	return uint8(Clamp(math.Round(v), 0, 255))
+}
+
+// Clamp - colour with every value kept within 0-255
+func (c Colour) Clamp() Colour {
+	return Colour{R: Clamp(c.R, 0, 255), G: Clamp(c.G, 0, 255), B: Clamp(c.B, 0, 255), A: Clamp(c.A, 0, 255)}
+}
+
+// Luma - brightness of the colour (0-255) as eyes see it
+func (c Colour) Luma() float64 {
+	return 0.299*c.R + 0.587*c.G + 0.114*c.B
+}
+
+// Lerp - colour t (0-1) of the way from c to d, alpha and all
+func (c Colour) Lerp(d Colour, t float64) Colour {
+	return Colour{
+		R: c.R + (d.R-c.R)*t,
+		G: c.G + (d.G-c.G)*t,
+		B: c.B + (d.B-c.B)*t,
+		A: c.A + (d.A-c.A)*t,
+	}
+}
+
+// LerpGamma - like Lerp but mixing the light rather than the sRGB values, so blends between bright colours don't
+// go muddy and dark in the middle
+func (c Colour) LerpGamma(d Colour, t float64) Colour {
+	mix := func(a, b float64) float64 {
+		return fromLinear(toLinear(a) + (toLinear(b)-toLinear(a))*t)
+	}
+	return Colour{R: mix(c.R, d.R), G: mix(c.G, d.G), B: mix(c.B, d.B), A: c.A + (d.A-c.A)*t}
+}
+
+// toLinear - sRGB value (0-255) to linear light (0-1)
+func toLinear(v float64) float64 {
+	v = Clamp01(v / 255)
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// fromLinear - linear light (0-1) to sRGB value (0-255)
+func fromLinear(v float64) float64 {
+	v = Clamp01(v)
+	if v <= 0.0031308 {
+		return v * 12.92 * 255
+	}
+	return (1.055*math.Pow(v, 1/2.4) - 0.055) * 255
+}
+
+// NewColourHSV - colour from hue (degrees), saturation and value (0-1), and alpha (0-255)
+func NewColourHSV(h, s, v, a float64) Colour {
+	return hueColour(h, v*s, v-v*s, a)
+}
+
+// NewColourHSL - colour from hue (degrees), saturation and lightness (0-1), and alpha (0-255)
+func NewColourHSL(h, s, l, a float64) Colour {
+	chroma := (1 - math.Abs(2*l-1)) * s
+	return hueColour(h, chroma, l-chroma/2, a)
+}
+
+// hueColour - colour of hue h with chroma ch (0-1) and m (0-1) added to every value
+func hueColour(h, ch, m, a float64) Colour {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	x := ch * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g = ch, x
+	case h < 120:
+		r, g = x, ch
+	case h < 180:
+		g, b = ch, x
+	case h < 240:
+		g, b = x, ch
+	case h < 300:
+		r, b = x, ch
+	default:
+		r, b = ch, x
+	}
+	return Colour{R: (r + m) * 255, G: (g + m) * 255, B: (b + m) * 255, A: a}
+}
+
+// hue - hue (degrees) of the colour, its biggest and smallest values (0-1)
+func (c Colour) hue() (h, max, min float64) {
+	r, g, b := Clamp01(c.R/255), Clamp01(c.G/255), Clamp01(c.B/255)
+	max, min = math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	d := max - min
+	switch {
+	case d == 0:
+		h = 0
+	case max == r:
+		h = math.Mod((g-b)/d, 6) * 60
+	case max == g:
+		h = ((b-r)/d + 2) * 60
+	default:
+		h = ((r-g)/d + 4) * 60
+	}
+	if h < 0 {
+		h += 360
+	}
+	return
+}
+
+// HSV - hue (degrees), saturation and value (0-1) of the colour
+func (c Colour) HSV() (h, s, v float64) {
+	h, max, min := c.hue()
+	if max > 0 {
+		s = (max - min) / max
+	}
+	return h, s, max
+}
+
+// HSL - hue (degrees), saturation and lightness (0-1) of the colour
+func (c Colour) HSL() (h, s, l float64) {
+	h, max, min := c.hue()
+	l = (max + min) / 2
+	if max != min {
+		s = (max - min) / (1 - math.Abs(2*l-1))
+	}
+	return h, s, l
+}
+
+// Brightness - colour lightened (amount > 0) or darkened (< 0) by amount (-1 to 1) of full brightness. Clamped
+func (c Colour) Brightness(amount float64) Colour {
+	d := amount * 255
+	return Colour{R: c.R + d, G: c.G + d, B: c.B + d, A: c.A}.Clamp()
+}
+
+// Contrast - colour pushed away from (amount > 1) or towards (amount < 1) mid grey. 1 leaves it alone. Clamped
+func (c Colour) Contrast(amount float64) Colour {
+	f := func(v float64) float64 { return (v-127.5)*amount + 127.5 }
+	return Colour{R: f(c.R), G: f(c.G), B: f(c.B), A: c.A}.Clamp()
+}
+
+// Saturation - colour made greyer (amount < 1, 0 is grey) or more colourful (amount > 1). Clamped
+func (c Colour) Saturation(amount float64) Colour {
+	grey := c.Luma()
+	return Colour{R: grey, G: grey, B: grey, A: c.A}.Lerp(c, amount).Clamp()
+}
+
+// CompositeOp - a Porter-Duff way of putting one colour over another
+type CompositeOp int
+
+// Porter-Duff operators. Src is the colour being drawn, Dst what is already there
+const (
+	CompositeClear CompositeOp = iota
+	CompositeSrc
+	CompositeDst
+	CompositeSrcOver
+	CompositeDstOver
+	CompositeSrcIn
+	CompositeDstIn
+	CompositeSrcOut
+	CompositeDstOut
+	CompositeSrcAtop
+	CompositeDstAtop
+	CompositeXor
+)
+
+// Composite - c drawn on dst with Porter-Duff operator op. Colours are not premultiplied
+func (c Colour) Composite(dst Colour, op CompositeOp) Colour {
+	as, ad := Clamp01(c.A/255), Clamp01(dst.A/255)
+	// how much of each colour's coverage survives
+	var fs, fd float64
+	switch op {
+	case CompositeSrc:
+		fs = 1
+	case CompositeDst:
+		fd = 1
+	case CompositeSrcOver:
+		fs, fd = 1, 1-as
+	case CompositeDstOver:
+		fs, fd = 1-ad, 1
+	case CompositeSrcIn:
+		fs = ad
+	case CompositeDstIn:
+		fd = as
+	case CompositeSrcOut:
+		fs = 1 - ad
+	case CompositeDstOut:
+		fd = 1 - as
+	case CompositeSrcAtop:
+		fs, fd = ad, 1-as
+	case CompositeDstAtop:
+		fs, fd = 1-ad, as
+	case CompositeXor:
+		fs, fd = 1-ad, 1-as
+	}
+	a := as*fs + ad*fd
+	if a == 0 {
+		return Colour{}
+	}
+	mix := func(s, d float64) float64 { return (s*as*fs + d*ad*fd) / a }
+	return Colour{R: mix(c.R, dst.R), G: mix(c.G, dst.G), B: mix(c.B, dst.B), A: a * 255}
+}
+
+// Over - c drawn over dst, the usual alpha blend (CompositeSrcOver)
+func (c Colour) Over(dst Colour) Colour {
+	return c.Composite(dst, CompositeSrcOver)
+}
+
+// GradientStop - colour at position T (0-1) along a gradient
+type GradientStop struct {
+	T   float64
+	Col Colour
+}
+
+// Gradient - colours blended between stops. Stops must be in order of T
+type Gradient []GradientStop
+
+// At - colour of the gradient at t. Before the first stop or after the last it is that stop's colour
+func (g Gradient) At(t float64) Colour {
+	return g.at(t, Colour.Lerp)
+}
+
+// AtGamma - like At but blending with LerpGamma
+func (g Gradient) AtGamma(t float64) Colour {
+	return g.at(t, Colour.LerpGamma)
+}
+
+func (g Gradient) at(t float64, lerp func(Colour, Colour, float64) Colour) Colour {
+	if len(g) == 0 {
+		return Colour{255, 255, 255, 255}
+	}
+	if t <= g[0].T {
+		return g[0].Col
+	}
+	for i := 1; i < len(g); i++ {
+		if t <= g[i].T {
+			a, b := g[i-1], g[i]
+			return lerp(a.Col, b.Col, (t-a.T)/(b.T-a.T))
+		}
+	}
+	return g[len(g)-1].Col
+}
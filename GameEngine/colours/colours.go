@@ -0,0 +1,144 @@
+// Package colours - named colours for GameEngine (the full X11 set plus CSS), parsing of colour strings and
+// palettes, loaded from GIMP .gpl and Lospec .hex files or the classic ones built in. Where X11 and CSS disagree
+// (gray, green, maroon and purple) the plain name is the X11 colour and the CSS one is called Web..., e.g. WebGreen
+package colours
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kevincolyer/GameEngine/GameEngine"
+)
+
+// x11.go is made from rgb.txt by gen.go
+//go:generate go run gen.go
+
+func rgb(r, g, b float64) GameEngine.Colour {
+	return GameEngine.Colour{R: r, G: g, B: b, A: 255}
+}
+
+// normalise - name in the form used as a key in named
+func normalise(name string) string {
+	name = strings.ToLower(name)
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(name)
+}
+
+// ColourByName - the named colour, ignoring case, spaces and underscores ("steelblue", "Steel Blue" and
+// "steel_blue" are all the same). ok is false if there is no such colour
+func ColourByName(name string) (c GameEngine.Colour, ok bool) {
+	c, ok = named[normalise(name)]
+	return
+}
+
+// Names - the names of every colour, in lower case and sorted
+func Names() []string {
+	names := make([]string, 0, len(named))
+	for n := range named {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse - colour from a string: a name ("steelblue"), hex ("#4682b4", "#48b", "#4682b480" with alpha, "0x4682b4"),
+// "rgb(70, 130, 180)", "rgba(70, 130, 180, 0.5)", "hsl(207, 44%, 49%)" or "hsla(...)". CSS style spaces
+// instead of commas and a "/ alpha" are fine too. Percentages work for rgb values and alpha
+func Parse(s string) (c GameEngine.Colour, err error) {
+	s = strings.TrimSpace(s)
+	l := strings.ToLower(s)
+	switch {
+	case strings.HasPrefix(l, "#"):
+		return ParseHex(l[1:])
+	case strings.HasPrefix(l, "0x"):
+		return ParseHex(l[2:])
+	case strings.HasPrefix(l, "rgb"), strings.HasPrefix(l, "hsl"):
+		return parseFunc(l)
+	}
+	if c, ok := ColourByName(s); ok {
+		return c, nil
+	}
+	// Lospec style hex without the #
+	if len(l) == 6 || len(l) == 8 {
+		if c, err := ParseHex(l); err == nil {
+			return c, nil
+		}
+	}
+	return c, fmt.Errorf("unknown colour %q", s)
+}
+
+// ParseHex - colour from hex digits without the leading #: rgb, rgba, rrggbb or rrggbbaa
+func ParseHex(h string) (c GameEngine.Colour, err error) {
+	switch len(h) {
+	case 3, 4:
+		// each digit doubled, f is ff
+		var long strings.Builder
+		for _, d := range h {
+			long.WriteRune(d)
+			long.WriteRune(d)
+		}
+		h = long.String()
+	case 6, 8:
+	default:
+		return c, fmt.Errorf("bad hex colour %q", h)
+	}
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return c, fmt.Errorf("bad hex colour %q", h)
+	}
+	if len(h) == 6 {
+		v = v<<8 | 0xff
+	}
+	return GameEngine.Colour{R: float64(v >> 24), G: float64(v >> 16 & 0xff), B: float64(v >> 8 & 0xff), A: float64(v & 0xff)}, nil
+}
+
+// parseFunc - rgb(), rgba(), hsl() and hsla()
+func parseFunc(s string) (c GameEngine.Colour, err error) {
+	open, end := strings.Index(s, "("), strings.LastIndex(s, ")")
+	if open < 0 || end < open || strings.TrimSpace(s[end+1:]) != "" {
+		return c, fmt.Errorf("bad colour %q", s)
+	}
+	fn := strings.TrimSpace(s[:open])
+	args := strings.FieldsFunc(strings.Replace(s[open+1:end], "/", ",", 1), func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(args) != 3 && len(args) != 4 {
+		return c, fmt.Errorf("bad colour %q: want 3 or 4 values", s)
+	}
+	v := make([]float64, 4)
+	v[3] = 1
+	for i, a := range args {
+		pct := strings.HasSuffix(a, "%")
+		if v[i], err = strconv.ParseFloat(strings.TrimSuffix(strings.TrimSuffix(a, "%"), "deg"), 64); err != nil {
+			return c, fmt.Errorf("bad colour %q: %q isn't a number", s, a)
+		}
+		switch {
+		case i == 3:
+			if pct {
+				v[i] /= 100
+			}
+		case fn == "rgb" || fn == "rgba":
+			if pct {
+				v[i] = v[i] * 255 / 100
+			}
+		case i > 0:
+			// saturation and lightness are always percentages
+			v[i] /= 100
+		}
+	}
+	a := GameEngine.Clamp01(v[3]) * 255
+	switch fn {
+	case "rgb", "rgba":
+		return GameEngine.Colour{R: clamp255(v[0]), G: clamp255(v[1]), B: clamp255(v[2]), A: a}, nil
+	case "hsl", "hsla":
+		c = GameEngine.NewColourHSL(v[0], GameEngine.Clamp01(v[1]), GameEngine.Clamp01(v[2]), a)
+		return GameEngine.Colour{R: clamp255(c.R), G: clamp255(c.G), B: clamp255(c.B), A: a}, nil
+	}
+	return c, fmt.Errorf("bad colour %q", s)
+}
+
+func clamp255(v float64) float64 {
+	return GameEngine.Clamp(math.Round(v), 0, 255)
+}
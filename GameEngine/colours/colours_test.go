@@ -0,0 +1,200 @@
+package colours
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kevincolyer/GameEngine/GameEngine"
+)
+
+func TestParse(t *testing.T) {
+	steel := GameEngine.Colour{R: 70, G: 130, B: 180, A: 255}
+	for _, tc := range []struct {
+		in   string
+		want GameEngine.Colour
+	}{
+		{"steelblue", steel},
+		{"Steel Blue", steel},
+		{"steel_blue", steel},
+		{"  #4682b4 ", steel},
+		{"#4682B4", steel},
+		{"0x4682b4", steel},
+		{"4682b4", steel},
+		{"#4682b480", GameEngine.Colour{R: 70, G: 130, B: 180, A: 128}},
+		{"#48b", GameEngine.Colour{R: 0x44, G: 0x88, B: 0xbb, A: 255}},
+		{"#48b0", GameEngine.Colour{R: 0x44, G: 0x88, B: 0xbb, A: 0}},
+		{"rgb(70, 130, 180)", steel},
+		{"RGB(70,130,180)", steel},
+		{"hsl(0, 100%, 50%)", GameEngine.Colour{R: 255, A: 255}},
+		{"webgreen", GameEngine.Colour{G: 128, A: 255}},
+		{"green", GameEngine.Colour{G: 255, A: 255}},
+	} {
+		got, err := Parse(tc.in)
+		if err != nil || got != tc.want {
+			t.Errorf("%q: got %v, %v, want %v", tc.in, got, err, tc.want)
+		}
+	}
+	for _, in := range []string{"", "nocolour", "#", "#12", "#12345", "#ggg", "0x", "12345g", "cmyk(1, 2, 3, 4)",
+		"rgb(1, 2)", "hsl"} {
+		if got, err := Parse(in); err == nil {
+			t.Errorf("%q: got %v, want an error", in, got)
+		}
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want GameEngine.Colour
+	}{
+		{"fff", GameEngine.Colour{R: 255, G: 255, B: 255, A: 255}},
+		{"0008", GameEngine.Colour{A: 0x88}},
+		{"be2633", GameEngine.Colour{R: 190, G: 38, B: 51, A: 255}},
+		{"BE263300", GameEngine.Colour{R: 190, G: 38, B: 51}},
+	} {
+		got, err := ParseHex(tc.in)
+		if err != nil || got != tc.want {
+			t.Errorf("%q: got %v, %v, want %v", tc.in, got, err, tc.want)
+		}
+	}
+	for _, in := range []string{"", "f", "ff", "fffff", "fffffff", "fffffffff", "ggg", "12 456", "-12345"} {
+		if got, err := ParseHex(in); err == nil {
+			t.Errorf("%q: got %v, want an error", in, got)
+		}
+	}
+}
+
+func TestParseFunc(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want GameEngine.Colour
+	}{
+		{"rgb(70, 130, 180)", GameEngine.Colour{R: 70, G: 130, B: 180, A: 255}},
+		{"rgb(70 130 180)", GameEngine.Colour{R: 70, G: 130, B: 180, A: 255}},
+		{"rgba(70,130,180,0.5)", GameEngine.Colour{R: 70, G: 130, B: 180, A: 127.5}},
+		{"rgb(70 130 180 / 50%)", GameEngine.Colour{R: 70, G: 130, B: 180, A: 127.5}},
+		{"rgba(70, 130, 180, 0)", GameEngine.Colour{R: 70, G: 130, B: 180}},
+		{"rgb(100%, 0%, 50%)", GameEngine.Colour{R: 255, B: 128, A: 255}},
+		{"rgb(300, -5, 20.4)", GameEngine.Colour{R: 255, B: 20, A: 255}},
+		{"rgba(1, 2, 3, 2)", GameEngine.Colour{R: 1, G: 2, B: 3, A: 255}},
+		{"hsl(0, 100%, 50%)", GameEngine.Colour{R: 255, A: 255}},
+		{"hsl(120deg 100% 25%)", GameEngine.Colour{G: 128, A: 255}},
+		{"hsl(240, 100%, 50%)", GameEngine.Colour{B: 255, A: 255}},
+		{"hsl(0, 0%, 100%)", GameEngine.Colour{R: 255, G: 255, B: 255, A: 255}},
+		{"hsla(240, 100%, 50%, 0.25)", GameEngine.Colour{B: 255, A: 63.75}},
+		{"hsl(240 100% 50% / 25%)", GameEngine.Colour{B: 255, A: 63.75}},
+		{"hsl(-120, 100%, 50%)", GameEngine.Colour{B: 255, A: 255}},
+		{"hsl(0, 200%, 150%)", GameEngine.Colour{R: 255, G: 255, B: 255, A: 255}},
+	} {
+		got, err := parseFunc(tc.in)
+		if err != nil || got != tc.want {
+			t.Errorf("%q: got %v, %v, want %v", tc.in, got, err, tc.want)
+		}
+	}
+	for _, in := range []string{"rgb", "rgb(", "rgb)1, 2, 3(", "rgb(1, 2)", "rgb(1, 2, 3, 4, 5)", "rgb(1, 2, x)",
+		"rgb(1, 2, 3", "rgb(1, 2, 3) 4", "rgbx(1, 2, 3)", "hsl(1deg, 2, 3%%)", "rgb(1 / 2 / 3)"} {
+		if got, err := parseFunc(in); err == nil {
+			t.Errorf("%q: got %v, want an error", in, got)
+		}
+	}
+}
+
+// four - the colours in testdata/four.gpl and four.hex
+var four = []GameEngine.Colour{
+	{R: 0, G: 0, B: 0, A: 255},
+	{R: 255, G: 255, B: 255, A: 255},
+	{R: 190, G: 38, B: 51, A: 255},
+	{R: 41, G: 173, B: 255, A: 255},
+}
+
+// samePalette - fails t if p doesn't have the colours and names wanted
+func samePalette(t *testing.T, p *Palette, name string, cols []GameEngine.Colour, names []string) {
+	t.Helper()
+	if p.Name != name {
+		t.Errorf("name %q, want %q", p.Name, name)
+	}
+	if len(p.Colours) != len(cols) || len(p.Names) != len(names) {
+		t.Fatalf("%d colours, %d names", len(p.Colours), len(p.Names))
+	}
+	for i := range cols {
+		if p.Colours[i] != cols[i] || p.Names[i] != names[i] {
+			t.Errorf("%d: got %v %q, want %v %q", i, p.Colours[i], p.Names[i], cols[i], names[i])
+		}
+	}
+}
+
+func TestLoadPalette(t *testing.T) {
+	p, err := LoadPalette("testdata/four.gpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	samePalette(t, p, "Test Four", four, []string{"Black", "White", "Dark Red", ""})
+	if i := p.Nearest(GameEngine.Colour{R: 200, G: 30, B: 40, A: 255}); i != 2 {
+		t.Errorf("nearest %d", i)
+	}
+	p, err = LoadPalette("testdata/four.hex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	samePalette(t, p, "four", four, []string{"", "", "", ""})
+	if _, err = LoadPalette("testdata/four.png"); err == nil {
+		t.Errorf("loaded a missing palette")
+	}
+	if _, err = LoadPalette("colours.go"); err == nil {
+		t.Errorf("loaded a .go file as a palette")
+	}
+}
+
+// lineOf - the line a ParseError is about, or -1 for any other error
+func lineOf(err error) int {
+	var pe *GameEngine.ParseError
+	if errors.As(err, &pe) {
+		return pe.Line
+	}
+	return -1
+}
+
+func TestParseGPL(t *testing.T) {
+	p, err := ParseGPL(strings.NewReader("GIMP Palette\r\n\r\n# comment\r\n1 2 3\r\n4 5 6 Blue Grey\r\n"), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	samePalette(t, p, "", []GameEngine.Colour{{R: 1, G: 2, B: 3, A: 255}, {R: 4, G: 5, B: 6, A: 255}},
+		[]string{"", "Blue Grey"})
+	for _, tc := range []struct {
+		in   string
+		line int
+	}{
+		{"", 0},
+		{"JASC-PAL\n", 1},
+		{"GIMP Palette\n1 2\n", 2},
+		{"GIMP Palette\nName: x\n1 2 256\n", 3},
+		{"GIMP Palette\n1 2 3\n-1 2 3\n", 3},
+		{"GIMP Palette\n1 two 3\n", 2},
+	} {
+		if _, err := ParseGPL(strings.NewReader(tc.in), "test"); lineOf(err) != tc.line {
+			t.Errorf("%q: got %v, want an error on line %d", tc.in, err, tc.line)
+		}
+	}
+}
+
+func TestParseHexPalette(t *testing.T) {
+	p, err := ParseHexPalette(strings.NewReader("#000000\n\n  FFFFFF  \nbe2633\n29adffff\n"), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	samePalette(t, p, "", four, []string{"", "", "", ""})
+	for _, tc := range []struct {
+		in   string
+		line int
+	}{
+		{"ffffff\nxyz\n", 2},
+		{"fffff\n", 1},
+		{"\n\nffffff\n#12\n", 4},
+	} {
+		if _, err := ParseHexPalette(strings.NewReader(tc.in), "test"); lineOf(err) != tc.line {
+			t.Errorf("%q: got %v, want an error on line %d", tc.in, err, tc.line)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+//go:build ignore
+
+// gen - writes x11.go from rgb.txt, the colour list that comes with X11, and the CSS colours below. Run it with
+// go generate
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+)
+
+// colour - a colour's Go name and its red, green and blue
+type colour struct {
+	name    string
+	r, g, b int
+}
+
+// css - CSS colours missing from X11, and the CSS versions of the colours the two disagree on
+var css = []colour{
+	{"Aqua", 0, 255, 255},
+	{"Crimson", 220, 20, 60},
+	{"Fuchsia", 255, 0, 255},
+	{"Indigo", 75, 0, 130},
+	{"Lime", 0, 255, 0},
+	{"Olive", 128, 128, 0},
+	{"RebeccaPurple", 102, 51, 153},
+	{"Silver", 192, 192, 192},
+	{"Teal", 0, 128, 128},
+	{"WebGray", 128, 128, 128},
+	{"WebGrey", 128, 128, 128},
+	{"WebGreen", 0, 128, 0},
+	{"WebMaroon", 128, 0, 0},
+	{"WebPurple", 128, 0, 128},
+	{"X11Gray", 190, 190, 190},
+	{"X11Grey", 190, 190, 190},
+	{"X11Green", 0, 255, 0},
+	{"X11Maroon", 176, 48, 96},
+	{"X11Purple", 160, 32, 240},
+}
+
+func main() {
+	x11, err := readRGB("rgb.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen.go from the X11 rgb.txt colour list plus the CSS colours it lacks. DO NOT EDIT.\n\n")
+	b.WriteString("package colours\n\nimport \"github.com/kevincolyer/GameEngine/GameEngine\"\n\n")
+	writeVars(&b, "X11 colours, as in x11-colours.png", x11)
+	writeVars(&b, "CSS colours missing from X11, and the CSS versions of the colours the two disagree on", css)
+	b.WriteString("// named - every colour by lower case name\nvar named = map[string]GameEngine.Colour{\n")
+	for _, c := range append(x11, css...) {
+		fmt.Fprintf(&b, "%q: %s,\n", strings.ToLower(c.name), c.name)
+	}
+	b.WriteString("}\n")
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = os.WriteFile("x11.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readRGB - the colours in an X11 rgb.txt. Most are there twice, as "ghost white" and "GhostWhite", and only the
+// names without spaces are kept
+func readRGB(filename string) (cs []colour, err error) {
+	infile, err := os.Open(filename)
+	if err != nil {
+		return
+	}
+	defer infile.Close()
+
+	scanner := bufio.NewScanner(infile)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "!") || len(fields) > 4 {
+			continue
+		}
+		var c colour
+		var name string
+		if _, err = fmt.Sscan(scanner.Text(), &c.r, &c.g, &c.b, &name); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", filename, line, err)
+		}
+		c.name = strings.ToUpper(name[:1]) + name[1:]
+		cs = append(cs, c)
+	}
+	return cs, scanner.Err()
+}
+
+// writeVars - a var block of colours
+func writeVars(b *bytes.Buffer, doc string, cs []colour) {
+	fmt.Fprintf(b, "// %s\nvar (\n", doc)
+	for _, c := range cs {
+		fmt.Fprintf(b, "%s = rgb(%d, %d, %d)\n", c.name, c.r, c.g, c.b)
+	}
+	b.WriteString(")\n\n")
+}
@@ -0,0 +1,117 @@
+package colours
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/kevincolyer/GameEngine/GameEngine"
+)
+
+// Palette - a list of colours, with names if the file gave them
+type Palette struct {
+	Name    string
+	Colours []GameEngine.Colour
+	Names   []string // same length as Colours, "" where there is no name
+}
+
+// LoadPalette - loads a GIMP .gpl or Lospec .hex palette, going by the file's extension
+func LoadPalette(filename string) (p *Palette, err error) {
+	infile, err := os.Open(filename)
+	if err != nil {
+		return
+	}
+	defer infile.Close()
+
+	name := filepath.Base(filename)
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".gpl":
+		return ParseGPL(infile, name)
+	case ".hex":
+		p, err = ParseHexPalette(infile, name)
+		if p != nil {
+			p.Name = strings.TrimSuffix(name, filepath.Ext(name))
+		}
+		return
+	}
+	return nil, fmt.Errorf("%s: unknown palette type", filename)
+}
+
+// ParseGPL - reads a GIMP palette. name is used in errors
+func ParseGPL(r io.Reader, name string) (p *Palette, err error) {
+	p = &Palette{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	fail := func(format string, a ...interface{}) error {
+		return &GameEngine.ParseError{File: name, Line: line, Msg: fmt.Sprintf(format, a...)}
+	}
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == 1:
+			if text != "GIMP Palette" {
+				return nil, fail("not a GIMP palette")
+			}
+			continue
+		case text == "" || strings.HasPrefix(text, "#"):
+			continue
+		case strings.HasPrefix(text, "Name:"):
+			p.Name = strings.TrimSpace(strings.TrimPrefix(text, "Name:"))
+			continue
+		case strings.HasPrefix(text, "Columns:"):
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) < 3 {
+			return nil, fail("want red green blue, got %q", text)
+		}
+		var v [3]float64
+		for i := range v {
+			n, err := strconv.Atoi(fields[i])
+			if err != nil || n < 0 || n > 255 {
+				return nil, fail("bad colour value %q", fields[i])
+			}
+			v[i] = float64(n)
+		}
+		p.Colours = append(p.Colours, rgb(v[0], v[1], v[2]))
+		p.Names = append(p.Names, strings.Join(fields[3:], " "))
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	if line == 0 {
+		return nil, fail("not a GIMP palette")
+	}
+	return
+}
+
+// ParseHexPalette - reads a Lospec .hex palette, one rrggbb colour a line. name is used in errors
+func ParseHexPalette(r io.Reader, name string) (p *Palette, err error) {
+	p = &Palette{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "#")
+		if text == "" {
+			continue
+		}
+		c, err := ParseHex(text)
+		if err != nil {
+			return nil, &GameEngine.ParseError{File: name, Line: line, Msg: err.Error()}
+		}
+		p.Colours = append(p.Colours, c)
+		p.Names = append(p.Names, "")
+	}
+	return p, scanner.Err()
+}
+
+// Nearest - index of the palette colour closest to c
+func (p *Palette) Nearest(c GameEngine.Colour) int {
+	return GameEngine.NearestColour(p.Colours, c)
+}
@@ -0,0 +1,33 @@
+package colours
+
+// hexPalette - palette from rrggbb strings, which must be good
+func hexPalette(name string, hex, names []string) *Palette {
+	p := &Palette{Name: name, Names: names}
+	for _, h := range hex {
+		c, err := ParseHex(h)
+		if err != nil {
+			panic(err)
+		}
+		p.Colours = append(p.Colours, c)
+	}
+	return p
+}
+
+// PICO8 - the 16 colours of the PICO-8 fantasy console
+var PICO8 = hexPalette("PICO-8",
+	[]string{"000000", "1d2b53", "7e2553", "008751", "ab5236", "5f574f", "c2c3c7", "fff1e8",
+		"ff004d", "ffa300", "ffec27", "00e436", "29adff", "83769c", "ff77a8", "ffccaa"},
+	[]string{"black", "dark blue", "dark purple", "dark green", "brown", "dark grey", "light grey", "white",
+		"red", "orange", "yellow", "green", "blue", "lavender", "pink", "light peach"})
+
+// GameBoy - the four greens of the original Game Boy, darkest first
+var GameBoy = hexPalette("Game Boy",
+	[]string{"0f380f", "306230", "8bac0f", "9bbc0f"},
+	[]string{"darkest", "dark", "light", "lightest"})
+
+// CGA - the 16 colours of the IBM CGA card, in the card's order
+var CGA = hexPalette("CGA",
+	[]string{"000000", "0000aa", "00aa00", "00aaaa", "aa0000", "aa00aa", "aa5500", "aaaaaa",
+		"555555", "5555ff", "55ff55", "55ffff", "ff5555", "ff55ff", "ffff55", "ffffff"},
+	[]string{"black", "blue", "green", "cyan", "red", "magenta", "brown", "light grey",
+		"dark grey", "light blue", "light green", "light cyan", "light red", "light magenta", "yellow", "white"})
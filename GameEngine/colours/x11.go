@@ -0,0 +1,1371 @@
+// Code generated by gen.go from the X11 rgb.txt colour list plus the CSS colours it lacks. DO NOT EDIT.
+
+package colours
+
+import "github.com/kevincolyer/GameEngine/GameEngine"
+
+// X11 colours, as in x11-colours.png
+var (
+	Snow                 = rgb(255, 250, 250)
+	GhostWhite           = rgb(248, 248, 255)
+	WhiteSmoke           = rgb(245, 245, 245)
+	Gainsboro            = rgb(220, 220, 220)
+	FloralWhite          = rgb(255, 250, 240)
+	OldLace              = rgb(253, 245, 230)
+	Linen                = rgb(250, 240, 230)
+	AntiqueWhite         = rgb(250, 235, 215)
+	PapayaWhip           = rgb(255, 239, 213)
+	BlanchedAlmond       = rgb(255, 235, 205)
+	Bisque               = rgb(255, 228, 196)
+	PeachPuff            = rgb(255, 218, 185)
+	NavajoWhite          = rgb(255, 222, 173)
+	Moccasin             = rgb(255, 228, 181)
+	Cornsilk             = rgb(255, 248, 220)
+	Ivory                = rgb(255, 255, 240)
+	LemonChiffon         = rgb(255, 250, 205)
+	Seashell             = rgb(255, 245, 238)
+	Honeydew             = rgb(240, 255, 240)
+	MintCream            = rgb(245, 255, 250)
+	Azure                = rgb(240, 255, 255)
+	AliceBlue            = rgb(240, 248, 255)
+	Lavender             = rgb(230, 230, 250)
+	LavenderBlush        = rgb(255, 240, 245)
+	MistyRose            = rgb(255, 228, 225)
+	White                = rgb(255, 255, 255)
+	Black                = rgb(0, 0, 0)
+	DarkSlateGray        = rgb(47, 79, 79)
+	DarkSlateGrey        = rgb(47, 79, 79)
+	DimGray              = rgb(105, 105, 105)
+	DimGrey              = rgb(105, 105, 105)
+	SlateGray            = rgb(112, 128, 144)
+	SlateGrey            = rgb(112, 128, 144)
+	LightSlateGray       = rgb(119, 136, 153)
+	LightSlateGrey       = rgb(119, 136, 153)
+	Gray                 = rgb(190, 190, 190)
+	Grey                 = rgb(190, 190, 190)
+	LightGrey            = rgb(211, 211, 211)
+	LightGray            = rgb(211, 211, 211)
+	MidnightBlue         = rgb(25, 25, 112)
+	Navy                 = rgb(0, 0, 128)
+	NavyBlue             = rgb(0, 0, 128)
+	CornflowerBlue       = rgb(100, 149, 237)
+	DarkSlateBlue        = rgb(72, 61, 139)
+	SlateBlue            = rgb(106, 90, 205)
+	MediumSlateBlue      = rgb(123, 104, 238)
+	LightSlateBlue       = rgb(132, 112, 255)
+	MediumBlue           = rgb(0, 0, 205)
+	RoyalBlue            = rgb(65, 105, 225)
+	Blue                 = rgb(0, 0, 255)
+	DodgerBlue           = rgb(30, 144, 255)
+	DeepSkyBlue          = rgb(0, 191, 255)
+	SkyBlue              = rgb(135, 206, 235)
+	LightSkyBlue         = rgb(135, 206, 250)
+	SteelBlue            = rgb(70, 130, 180)
+	LightSteelBlue       = rgb(176, 196, 222)
+	LightBlue            = rgb(173, 216, 230)
+	PowderBlue           = rgb(176, 224, 230)
+	PaleTurquoise        = rgb(175, 238, 238)
+	DarkTurquoise        = rgb(0, 206, 209)
+	MediumTurquoise      = rgb(72, 209, 204)
+	Turquoise            = rgb(64, 224, 208)
+	Cyan                 = rgb(0, 255, 255)
+	LightCyan            = rgb(224, 255, 255)
+	CadetBlue            = rgb(95, 158, 160)
+	MediumAquamarine     = rgb(102, 205, 170)
+	Aquamarine           = rgb(127, 255, 212)
+	DarkGreen            = rgb(0, 100, 0)
+	DarkOliveGreen       = rgb(85, 107, 47)
+	DarkSeaGreen         = rgb(143, 188, 143)
+	SeaGreen             = rgb(46, 139, 87)
+	MediumSeaGreen       = rgb(60, 179, 113)
+	LightSeaGreen        = rgb(32, 178, 170)
+	PaleGreen            = rgb(152, 251, 152)
+	SpringGreen          = rgb(0, 255, 127)
+	LawnGreen            = rgb(124, 252, 0)
+	Green                = rgb(0, 255, 0)
+	Chartreuse           = rgb(127, 255, 0)
+	MediumSpringGreen    = rgb(0, 250, 154)
+	GreenYellow          = rgb(173, 255, 47)
+	LimeGreen            = rgb(50, 205, 50)
+	YellowGreen          = rgb(154, 205, 50)
+	ForestGreen          = rgb(34, 139, 34)
+	OliveDrab            = rgb(107, 142, 35)
+	DarkKhaki            = rgb(189, 183, 107)
+	Khaki                = rgb(240, 230, 140)
+	PaleGoldenrod        = rgb(238, 232, 170)
+	LightGoldenrodYellow = rgb(250, 250, 210)
+	LightYellow          = rgb(255, 255, 224)
+	Yellow               = rgb(255, 255, 0)
+	Gold                 = rgb(255, 215, 0)
+	LightGoldenrod       = rgb(238, 221, 130)
+	Goldenrod            = rgb(218, 165, 32)
+	DarkGoldenrod        = rgb(184, 134, 11)
+	RosyBrown            = rgb(188, 143, 143)
+	IndianRed            = rgb(205, 92, 92)
+	SaddleBrown          = rgb(139, 69, 19)
+	Sienna               = rgb(160, 82, 45)
+	Peru                 = rgb(205, 133, 63)
+	Burlywood            = rgb(222, 184, 135)
+	Beige                = rgb(245, 245, 220)
+	Wheat                = rgb(245, 222, 179)
+	SandyBrown           = rgb(244, 164, 96)
+	Tan                  = rgb(210, 180, 140)
+	Chocolate            = rgb(210, 105, 30)
+	Firebrick            = rgb(178, 34, 34)
+	Brown                = rgb(165, 42, 42)
+	DarkSalmon           = rgb(233, 150, 122)
+	Salmon               = rgb(250, 128, 114)
+	LightSalmon          = rgb(255, 160, 122)
+	Orange               = rgb(255, 165, 0)
+	DarkOrange           = rgb(255, 140, 0)
+	Coral                = rgb(255, 127, 80)
+	LightCoral           = rgb(240, 128, 128)
+	Tomato               = rgb(255, 99, 71)
+	OrangeRed            = rgb(255, 69, 0)
+	Red                  = rgb(255, 0, 0)
+	HotPink              = rgb(255, 105, 180)
+	DeepPink             = rgb(255, 20, 147)
+	Pink                 = rgb(255, 192, 203)
+	LightPink            = rgb(255, 182, 193)
+	PaleVioletRed        = rgb(219, 112, 147)
+	Maroon               = rgb(176, 48, 96)
+	MediumVioletRed      = rgb(199, 21, 133)
+	VioletRed            = rgb(208, 32, 144)
+	Magenta              = rgb(255, 0, 255)
+	Violet               = rgb(238, 130, 238)
+	Plum                 = rgb(221, 160, 221)
+	Orchid               = rgb(218, 112, 214)
+	MediumOrchid         = rgb(186, 85, 211)
+	DarkOrchid           = rgb(153, 50, 204)
+	DarkViolet           = rgb(148, 0, 211)
+	BlueViolet           = rgb(138, 43, 226)
+	Purple               = rgb(160, 32, 240)
+	MediumPurple         = rgb(147, 112, 219)
+	Thistle              = rgb(216, 191, 216)
+	Snow1                = rgb(255, 250, 250)
+	Snow2                = rgb(238, 233, 233)
+	Snow3                = rgb(205, 201, 201)
+	Snow4                = rgb(139, 137, 137)
+	Seashell1            = rgb(255, 245, 238)
+	Seashell2            = rgb(238, 229, 222)
+	Seashell3            = rgb(205, 197, 191)
+	Seashell4            = rgb(139, 134, 130)
+	AntiqueWhite1        = rgb(255, 239, 219)
+	AntiqueWhite2        = rgb(238, 223, 204)
+	AntiqueWhite3        = rgb(205, 192, 176)
+	AntiqueWhite4        = rgb(139, 131, 120)
+	Bisque1              = rgb(255, 228, 196)
+	Bisque2              = rgb(238, 213, 183)
+	Bisque3              = rgb(205, 183, 158)
+	Bisque4              = rgb(139, 125, 107)
+	PeachPuff1           = rgb(255, 218, 185)
+	PeachPuff2           = rgb(238, 203, 173)
+	PeachPuff3           = rgb(205, 175, 149)
+	PeachPuff4           = rgb(139, 119, 101)
+	NavajoWhite1         = rgb(255, 222, 173)
+	NavajoWhite2         = rgb(238, 207, 161)
+	NavajoWhite3         = rgb(205, 179, 139)
+	NavajoWhite4         = rgb(139, 121, 94)
+	LemonChiffon1        = rgb(255, 250, 205)
+	LemonChiffon2        = rgb(238, 233, 191)
+	LemonChiffon3        = rgb(205, 201, 165)
+	LemonChiffon4        = rgb(139, 137, 112)
+	Cornsilk1            = rgb(255, 248, 220)
+	Cornsilk2            = rgb(238, 232, 205)
+	Cornsilk3            = rgb(205, 200, 177)
+	Cornsilk4            = rgb(139, 136, 120)
+	Ivory1               = rgb(255, 255, 240)
+	Ivory2               = rgb(238, 238, 224)
+	Ivory3               = rgb(205, 205, 193)
+	Ivory4               = rgb(139, 139, 131)
+	Honeydew1            = rgb(240, 255, 240)
+	Honeydew2            = rgb(224, 238, 224)
+	Honeydew3            = rgb(193, 205, 193)
+	Honeydew4            = rgb(131, 139, 131)
+	LavenderBlush1       = rgb(255, 240, 245)
+	LavenderBlush2       = rgb(238, 224, 229)
+	LavenderBlush3       = rgb(205, 193, 197)
+	LavenderBlush4       = rgb(139, 131, 134)
+	MistyRose1           = rgb(255, 228, 225)
+	MistyRose2           = rgb(238, 213, 210)
+	MistyRose3           = rgb(205, 183, 181)
+	MistyRose4           = rgb(139, 125, 123)
+	Azure1               = rgb(240, 255, 255)
+	Azure2               = rgb(224, 238, 238)
+	Azure3               = rgb(193, 205, 205)
+	Azure4               = rgb(131, 139, 139)
+	SlateBlue1           = rgb(131, 111, 255)
+	SlateBlue2           = rgb(122, 103, 238)
+	SlateBlue3           = rgb(105, 89, 205)
+	SlateBlue4           = rgb(71, 60, 139)
+	RoyalBlue1           = rgb(72, 118, 255)
+	RoyalBlue2           = rgb(67, 110, 238)
+	RoyalBlue3           = rgb(58, 95, 205)
+	RoyalBlue4           = rgb(39, 64, 139)
+	Blue1                = rgb(0, 0, 255)
+	Blue2                = rgb(0, 0, 238)
+	Blue3                = rgb(0, 0, 205)
+	Blue4                = rgb(0, 0, 139)
+	DodgerBlue1          = rgb(30, 144, 255)
+	DodgerBlue2          = rgb(28, 134, 238)
+	DodgerBlue3          = rgb(24, 116, 205)
+	DodgerBlue4          = rgb(16, 78, 139)
+	SteelBlue1           = rgb(99, 184, 255)
+	SteelBlue2           = rgb(92, 172, 238)
+	SteelBlue3           = rgb(79, 148, 205)
+	SteelBlue4           = rgb(54, 100, 139)
+	DeepSkyBlue1         = rgb(0, 191, 255)
+	DeepSkyBlue2         = rgb(0, 178, 238)
+	DeepSkyBlue3         = rgb(0, 154, 205)
+	DeepSkyBlue4         = rgb(0, 104, 139)
+	SkyBlue1             = rgb(135, 206, 255)
+	SkyBlue2             = rgb(126, 192, 238)
+	SkyBlue3             = rgb(108, 166, 205)
+	SkyBlue4             = rgb(74, 112, 139)
+	LightSkyBlue1        = rgb(176, 226, 255)
+	LightSkyBlue2        = rgb(164, 211, 238)
+	LightSkyBlue3        = rgb(141, 182, 205)
+	LightSkyBlue4        = rgb(96, 123, 139)
+	SlateGray1           = rgb(198, 226, 255)
+	SlateGray2           = rgb(185, 211, 238)
+	SlateGray3           = rgb(159, 182, 205)
+	SlateGray4           = rgb(108, 123, 139)
+	LightSteelBlue1      = rgb(202, 225, 255)
+	LightSteelBlue2      = rgb(188, 210, 238)
+	LightSteelBlue3      = rgb(162, 181, 205)
+	LightSteelBlue4      = rgb(110, 123, 139)
+	LightBlue1           = rgb(191, 239, 255)
+	LightBlue2           = rgb(178, 223, 238)
+	LightBlue3           = rgb(154, 192, 205)
+	LightBlue4           = rgb(104, 131, 139)
+	LightCyan1           = rgb(224, 255, 255)
+	LightCyan2           = rgb(209, 238, 238)
+	LightCyan3           = rgb(180, 205, 205)
+	LightCyan4           = rgb(122, 139, 139)
+	PaleTurquoise1       = rgb(187, 255, 255)
+	PaleTurquoise2       = rgb(174, 238, 238)
+	PaleTurquoise3       = rgb(150, 205, 205)
+	PaleTurquoise4       = rgb(102, 139, 139)
+	CadetBlue1           = rgb(152, 245, 255)
+	CadetBlue2           = rgb(142, 229, 238)
+	CadetBlue3           = rgb(122, 197, 205)
+	CadetBlue4           = rgb(83, 134, 139)
+	Turquoise1           = rgb(0, 245, 255)
+	Turquoise2           = rgb(0, 229, 238)
+	Turquoise3           = rgb(0, 197, 205)
+	Turquoise4           = rgb(0, 134, 139)
+	Cyan1                = rgb(0, 255, 255)
+	Cyan2                = rgb(0, 238, 238)
+	Cyan3                = rgb(0, 205, 205)
+	Cyan4                = rgb(0, 139, 139)
+	DarkSlateGray1       = rgb(151, 255, 255)
+	DarkSlateGray2       = rgb(141, 238, 238)
+	DarkSlateGray3       = rgb(121, 205, 205)
+	DarkSlateGray4       = rgb(82, 139, 139)
+	Aquamarine1          = rgb(127, 255, 212)
+	Aquamarine2          = rgb(118, 238, 198)
+	Aquamarine3          = rgb(102, 205, 170)
+	Aquamarine4          = rgb(69, 139, 116)
+	DarkSeaGreen1        = rgb(193, 255, 193)
+	DarkSeaGreen2        = rgb(180, 238, 180)
+	DarkSeaGreen3        = rgb(155, 205, 155)
+	DarkSeaGreen4        = rgb(105, 139, 105)
+	SeaGreen1            = rgb(84, 255, 159)
+	SeaGreen2            = rgb(78, 238, 148)
+	SeaGreen3            = rgb(67, 205, 128)
+	SeaGreen4            = rgb(46, 139, 87)
+	PaleGreen1           = rgb(154, 255, 154)
+	PaleGreen2           = rgb(144, 238, 144)
+	PaleGreen3           = rgb(124, 205, 124)
+	PaleGreen4           = rgb(84, 139, 84)
+	SpringGreen1         = rgb(0, 255, 127)
+	SpringGreen2         = rgb(0, 238, 118)
+	SpringGreen3         = rgb(0, 205, 102)
+	SpringGreen4         = rgb(0, 139, 69)
+	Green1               = rgb(0, 255, 0)
+	Green2               = rgb(0, 238, 0)
+	Green3               = rgb(0, 205, 0)
+	Green4               = rgb(0, 139, 0)
+	Chartreuse1          = rgb(127, 255, 0)
+	Chartreuse2          = rgb(118, 238, 0)
+	Chartreuse3          = rgb(102, 205, 0)
+	Chartreuse4          = rgb(69, 139, 0)
+	OliveDrab1           = rgb(192, 255, 62)
+	OliveDrab2           = rgb(179, 238, 58)
+	OliveDrab3           = rgb(154, 205, 50)
+	OliveDrab4           = rgb(105, 139, 34)
+	DarkOliveGreen1      = rgb(202, 255, 112)
+	DarkOliveGreen2      = rgb(188, 238, 104)
+	DarkOliveGreen3      = rgb(162, 205, 90)
+	DarkOliveGreen4      = rgb(110, 139, 61)
+	Khaki1               = rgb(255, 246, 143)
+	Khaki2               = rgb(238, 230, 133)
+	Khaki3               = rgb(205, 198, 115)
+	Khaki4               = rgb(139, 134, 78)
+	LightGoldenrod1      = rgb(255, 236, 139)
+	LightGoldenrod2      = rgb(238, 220, 130)
+	LightGoldenrod3      = rgb(205, 190, 112)
+	LightGoldenrod4      = rgb(139, 129, 76)
+	LightYellow1         = rgb(255, 255, 224)
+	LightYellow2         = rgb(238, 238, 209)
+	LightYellow3         = rgb(205, 205, 180)
+	LightYellow4         = rgb(139, 139, 122)
+	Yellow1              = rgb(255, 255, 0)
+	Yellow2              = rgb(238, 238, 0)
+	Yellow3              = rgb(205, 205, 0)
+	Yellow4              = rgb(139, 139, 0)
+	Gold1                = rgb(255, 215, 0)
+	Gold2                = rgb(238, 201, 0)
+	Gold3                = rgb(205, 173, 0)
+	Gold4                = rgb(139, 117, 0)
+	Goldenrod1           = rgb(255, 193, 37)
+	Goldenrod2           = rgb(238, 180, 34)
+	Goldenrod3           = rgb(205, 155, 29)
+	Goldenrod4           = rgb(139, 105, 20)
+	DarkGoldenrod1       = rgb(255, 185, 15)
+	DarkGoldenrod2       = rgb(238, 173, 14)
+	DarkGoldenrod3       = rgb(205, 149, 12)
+	DarkGoldenrod4       = rgb(139, 101, 8)
+	RosyBrown1           = rgb(255, 193, 193)
+	RosyBrown2           = rgb(238, 180, 180)
+	RosyBrown3           = rgb(205, 155, 155)
+	RosyBrown4           = rgb(139, 105, 105)
+	IndianRed1           = rgb(255, 106, 106)
+	IndianRed2           = rgb(238, 99, 99)
+	IndianRed3           = rgb(205, 85, 85)
+	IndianRed4           = rgb(139, 58, 58)
+	Sienna1              = rgb(255, 130, 71)
+	Sienna2              = rgb(238, 121, 66)
+	Sienna3              = rgb(205, 104, 57)
+	Sienna4              = rgb(139, 71, 38)
+	Burlywood1           = rgb(255, 211, 155)
+	Burlywood2           = rgb(238, 197, 145)
+	Burlywood3           = rgb(205, 170, 125)
+	Burlywood4           = rgb(139, 115, 85)
+	Wheat1               = rgb(255, 231, 186)
+	Wheat2               = rgb(238, 216, 174)
+	Wheat3               = rgb(205, 186, 150)
+	Wheat4               = rgb(139, 126, 102)
+	Tan1                 = rgb(255, 165, 79)
+	Tan2                 = rgb(238, 154, 73)
+	Tan3                 = rgb(205, 133, 63)
+	Tan4                 = rgb(139, 90, 43)
+	Chocolate1           = rgb(255, 127, 36)
+	Chocolate2           = rgb(238, 118, 33)
+	Chocolate3           = rgb(205, 102, 29)
+	Chocolate4           = rgb(139, 69, 19)
+	Firebrick1           = rgb(255, 48, 48)
+	Firebrick2           = rgb(238, 44, 44)
+	Firebrick3           = rgb(205, 38, 38)
+	Firebrick4           = rgb(139, 26, 26)
+	Brown1               = rgb(255, 64, 64)
+	Brown2               = rgb(238, 59, 59)
+	Brown3               = rgb(205, 51, 51)
+	Brown4               = rgb(139, 35, 35)
+	Salmon1              = rgb(255, 140, 105)
+	Salmon2              = rgb(238, 130, 98)
+	Salmon3              = rgb(205, 112, 84)
+	Salmon4              = rgb(139, 76, 57)
+	LightSalmon1         = rgb(255, 160, 122)
+	LightSalmon2         = rgb(238, 149, 114)
+	LightSalmon3         = rgb(205, 129, 98)
+	LightSalmon4         = rgb(139, 87, 66)
+	Orange1              = rgb(255, 165, 0)
+	Orange2              = rgb(238, 154, 0)
+	Orange3              = rgb(205, 133, 0)
+	Orange4              = rgb(139, 90, 0)
+	DarkOrange1          = rgb(255, 127, 0)
+	DarkOrange2          = rgb(238, 118, 0)
+	DarkOrange3          = rgb(205, 102, 0)
+	DarkOrange4          = rgb(139, 69, 0)
+	Coral1               = rgb(255, 114, 86)
+	Coral2               = rgb(238, 106, 80)
+	Coral3               = rgb(205, 91, 69)
+	Coral4               = rgb(139, 62, 47)
+	Tomato1              = rgb(255, 99, 71)
+	Tomato2              = rgb(238, 92, 66)
+	Tomato3              = rgb(205, 79, 57)
+	Tomato4              = rgb(139, 54, 38)
+	OrangeRed1           = rgb(255, 69, 0)
+	OrangeRed2           = rgb(238, 64, 0)
+	OrangeRed3           = rgb(205, 55, 0)
+	OrangeRed4           = rgb(139, 37, 0)
+	Red1                 = rgb(255, 0, 0)
+	Red2                 = rgb(238, 0, 0)
+	Red3                 = rgb(205, 0, 0)
+	Red4                 = rgb(139, 0, 0)
+	DebianRed            = rgb(215, 7, 81)
+	DeepPink1            = rgb(255, 20, 147)
+	DeepPink2            = rgb(238, 18, 137)
+	DeepPink3            = rgb(205, 16, 118)
+	DeepPink4            = rgb(139, 10, 80)
+	HotPink1             = rgb(255, 110, 180)
+	HotPink2             = rgb(238, 106, 167)
+	HotPink3             = rgb(205, 96, 144)
+	HotPink4             = rgb(139, 58, 98)
+	Pink1                = rgb(255, 181, 197)
+	Pink2                = rgb(238, 169, 184)
+	Pink3                = rgb(205, 145, 158)
+	Pink4                = rgb(139, 99, 108)
+	LightPink1           = rgb(255, 174, 185)
+	LightPink2           = rgb(238, 162, 173)
+	LightPink3           = rgb(205, 140, 149)
+	LightPink4           = rgb(139, 95, 101)
+	PaleVioletRed1       = rgb(255, 130, 171)
+	PaleVioletRed2       = rgb(238, 121, 159)
+	PaleVioletRed3       = rgb(205, 104, 137)
+	PaleVioletRed4       = rgb(139, 71, 93)
+	Maroon1              = rgb(255, 52, 179)
+	Maroon2              = rgb(238, 48, 167)
+	Maroon3              = rgb(205, 41, 144)
+	Maroon4              = rgb(139, 28, 98)
+	VioletRed1           = rgb(255, 62, 150)
+	VioletRed2           = rgb(238, 58, 140)
+	VioletRed3           = rgb(205, 50, 120)
+	VioletRed4           = rgb(139, 34, 82)
+	Magenta1             = rgb(255, 0, 255)
+	Magenta2             = rgb(238, 0, 238)
+	Magenta3             = rgb(205, 0, 205)
+	Magenta4             = rgb(139, 0, 139)
+	Orchid1              = rgb(255, 131, 250)
+	Orchid2              = rgb(238, 122, 233)
+	Orchid3              = rgb(205, 105, 201)
+	Orchid4              = rgb(139, 71, 137)
+	Plum1                = rgb(255, 187, 255)
+	Plum2                = rgb(238, 174, 238)
+	Plum3                = rgb(205, 150, 205)
+	Plum4                = rgb(139, 102, 139)
+	MediumOrchid1        = rgb(224, 102, 255)
+	MediumOrchid2        = rgb(209, 95, 238)
+	MediumOrchid3        = rgb(180, 82, 205)
+	MediumOrchid4        = rgb(122, 55, 139)
+	DarkOrchid1          = rgb(191, 62, 255)
+	DarkOrchid2          = rgb(178, 58, 238)
+	DarkOrchid3          = rgb(154, 50, 205)
+	DarkOrchid4          = rgb(104, 34, 139)
+	Purple1              = rgb(155, 48, 255)
+	Purple2              = rgb(145, 44, 238)
+	Purple3              = rgb(125, 38, 205)
+	Purple4              = rgb(85, 26, 139)
+	MediumPurple1        = rgb(171, 130, 255)
+	MediumPurple2        = rgb(159, 121, 238)
+	MediumPurple3        = rgb(137, 104, 205)
+	MediumPurple4        = rgb(93, 71, 139)
+	Thistle1             = rgb(255, 225, 255)
+	Thistle2             = rgb(238, 210, 238)
+	Thistle3             = rgb(205, 181, 205)
+	Thistle4             = rgb(139, 123, 139)
+	Gray0                = rgb(0, 0, 0)
+	Grey0                = rgb(0, 0, 0)
+	Gray1                = rgb(3, 3, 3)
+	Grey1                = rgb(3, 3, 3)
+	Gray2                = rgb(5, 5, 5)
+	Grey2                = rgb(5, 5, 5)
+	Gray3                = rgb(8, 8, 8)
+	Grey3                = rgb(8, 8, 8)
+	Gray4                = rgb(10, 10, 10)
+	Grey4                = rgb(10, 10, 10)
+	Gray5                = rgb(13, 13, 13)
+	Grey5                = rgb(13, 13, 13)
+	Gray6                = rgb(15, 15, 15)
+	Grey6                = rgb(15, 15, 15)
+	Gray7                = rgb(18, 18, 18)
+	Grey7                = rgb(18, 18, 18)
+	Gray8                = rgb(20, 20, 20)
+	Grey8                = rgb(20, 20, 20)
+	Gray9                = rgb(23, 23, 23)
+	Grey9                = rgb(23, 23, 23)
+	Gray10               = rgb(26, 26, 26)
+	Grey10               = rgb(26, 26, 26)
+	Gray11               = rgb(28, 28, 28)
+	Grey11               = rgb(28, 28, 28)
+	Gray12               = rgb(31, 31, 31)
+	Grey12               = rgb(31, 31, 31)
+	Gray13               = rgb(33, 33, 33)
+	Grey13               = rgb(33, 33, 33)
+	Gray14               = rgb(36, 36, 36)
+	Grey14               = rgb(36, 36, 36)
+	Gray15               = rgb(38, 38, 38)
+	Grey15               = rgb(38, 38, 38)
+	Gray16               = rgb(41, 41, 41)
+	Grey16               = rgb(41, 41, 41)
+	Gray17               = rgb(43, 43, 43)
+	Grey17               = rgb(43, 43, 43)
+	Gray18               = rgb(46, 46, 46)
+	Grey18               = rgb(46, 46, 46)
+	Gray19               = rgb(48, 48, 48)
+	Grey19               = rgb(48, 48, 48)
+	Gray20               = rgb(51, 51, 51)
+	Grey20               = rgb(51, 51, 51)
+	Gray21               = rgb(54, 54, 54)
+	Grey21               = rgb(54, 54, 54)
+	Gray22               = rgb(56, 56, 56)
+	Grey22               = rgb(56, 56, 56)
+	Gray23               = rgb(59, 59, 59)
+	Grey23               = rgb(59, 59, 59)
+	Gray24               = rgb(61, 61, 61)
+	Grey24               = rgb(61, 61, 61)
+	Gray25               = rgb(64, 64, 64)
+	Grey25               = rgb(64, 64, 64)
+	Gray26               = rgb(66, 66, 66)
+	Grey26               = rgb(66, 66, 66)
+	Gray27               = rgb(69, 69, 69)
+	Grey27               = rgb(69, 69, 69)
+	Gray28               = rgb(71, 71, 71)
+	Grey28               = rgb(71, 71, 71)
+	Gray29               = rgb(74, 74, 74)
+	Grey29               = rgb(74, 74, 74)
+	Gray30               = rgb(77, 77, 77)
+	Grey30               = rgb(77, 77, 77)
+	Gray31               = rgb(79, 79, 79)
+	Grey31               = rgb(79, 79, 79)
+	Gray32               = rgb(82, 82, 82)
+	Grey32               = rgb(82, 82, 82)
+	Gray33               = rgb(84, 84, 84)
+	Grey33               = rgb(84, 84, 84)
+	Gray34               = rgb(87, 87, 87)
+	Grey34               = rgb(87, 87, 87)
+	Gray35               = rgb(89, 89, 89)
+	Grey35               = rgb(89, 89, 89)
+	Gray36               = rgb(92, 92, 92)
+	Grey36               = rgb(92, 92, 92)
+	Gray37               = rgb(94, 94, 94)
+	Grey37               = rgb(94, 94, 94)
+	Gray38               = rgb(97, 97, 97)
+	Grey38               = rgb(97, 97, 97)
+	Gray39               = rgb(99, 99, 99)
+	Grey39               = rgb(99, 99, 99)
+	Gray40               = rgb(102, 102, 102)
+	Grey40               = rgb(102, 102, 102)
+	Gray41               = rgb(105, 105, 105)
+	Grey41               = rgb(105, 105, 105)
+	Gray42               = rgb(107, 107, 107)
+	Grey42               = rgb(107, 107, 107)
+	Gray43               = rgb(110, 110, 110)
+	Grey43               = rgb(110, 110, 110)
+	Gray44               = rgb(112, 112, 112)
+	Grey44               = rgb(112, 112, 112)
+	Gray45               = rgb(115, 115, 115)
+	Grey45               = rgb(115, 115, 115)
+	Gray46               = rgb(117, 117, 117)
+	Grey46               = rgb(117, 117, 117)
+	Gray47               = rgb(120, 120, 120)
+	Grey47               = rgb(120, 120, 120)
+	Gray48               = rgb(122, 122, 122)
+	Grey48               = rgb(122, 122, 122)
+	Gray49               = rgb(125, 125, 125)
+	Grey49               = rgb(125, 125, 125)
+	Gray50               = rgb(127, 127, 127)
+	Grey50               = rgb(127, 127, 127)
+	Gray51               = rgb(130, 130, 130)
+	Grey51               = rgb(130, 130, 130)
+	Gray52               = rgb(133, 133, 133)
+	Grey52               = rgb(133, 133, 133)
+	Gray53               = rgb(135, 135, 135)
+	Grey53               = rgb(135, 135, 135)
+	Gray54               = rgb(138, 138, 138)
+	Grey54               = rgb(138, 138, 138)
+	Gray55               = rgb(140, 140, 140)
+	Grey55               = rgb(140, 140, 140)
+	Gray56               = rgb(143, 143, 143)
+	Grey56               = rgb(143, 143, 143)
+	Gray57               = rgb(145, 145, 145)
+	Grey57               = rgb(145, 145, 145)
+	Gray58               = rgb(148, 148, 148)
+	Grey58               = rgb(148, 148, 148)
+	Gray59               = rgb(150, 150, 150)
+	Grey59               = rgb(150, 150, 150)
+	Gray60               = rgb(153, 153, 153)
+	Grey60               = rgb(153, 153, 153)
+	Gray61               = rgb(156, 156, 156)
+	Grey61               = rgb(156, 156, 156)
+	Gray62               = rgb(158, 158, 158)
+	Grey62               = rgb(158, 158, 158)
+	Gray63               = rgb(161, 161, 161)
+	Grey63               = rgb(161, 161, 161)
+	Gray64               = rgb(163, 163, 163)
+	Grey64               = rgb(163, 163, 163)
+	Gray65               = rgb(166, 166, 166)
+	Grey65               = rgb(166, 166, 166)
+	Gray66               = rgb(168, 168, 168)
+	Grey66               = rgb(168, 168, 168)
+	Gray67               = rgb(171, 171, 171)
+	Grey67               = rgb(171, 171, 171)
+	Gray68               = rgb(173, 173, 173)
+	Grey68               = rgb(173, 173, 173)
+	Gray69               = rgb(176, 176, 176)
+	Grey69               = rgb(176, 176, 176)
+	Gray70               = rgb(179, 179, 179)
+	Grey70               = rgb(179, 179, 179)
+	Gray71               = rgb(181, 181, 181)
+	Grey71               = rgb(181, 181, 181)
+	Gray72               = rgb(184, 184, 184)
+	Grey72               = rgb(184, 184, 184)
+	Gray73               = rgb(186, 186, 186)
+	Grey73               = rgb(186, 186, 186)
+	Gray74               = rgb(189, 189, 189)
+	Grey74               = rgb(189, 189, 189)
+	Gray75               = rgb(191, 191, 191)
+	Grey75               = rgb(191, 191, 191)
+	Gray76               = rgb(194, 194, 194)
+	Grey76               = rgb(194, 194, 194)
+	Gray77               = rgb(196, 196, 196)
+	Grey77               = rgb(196, 196, 196)
+	Gray78               = rgb(199, 199, 199)
+	Grey78               = rgb(199, 199, 199)
+	Gray79               = rgb(201, 201, 201)
+	Grey79               = rgb(201, 201, 201)
+	Gray80               = rgb(204, 204, 204)
+	Grey80               = rgb(204, 204, 204)
+	Gray81               = rgb(207, 207, 207)
+	Grey81               = rgb(207, 207, 207)
+	Gray82               = rgb(209, 209, 209)
+	Grey82               = rgb(209, 209, 209)
+	Gray83               = rgb(212, 212, 212)
+	Grey83               = rgb(212, 212, 212)
+	Gray84               = rgb(214, 214, 214)
+	Grey84               = rgb(214, 214, 214)
+	Gray85               = rgb(217, 217, 217)
+	Grey85               = rgb(217, 217, 217)
+	Gray86               = rgb(219, 219, 219)
+	Grey86               = rgb(219, 219, 219)
+	Gray87               = rgb(222, 222, 222)
+	Grey87               = rgb(222, 222, 222)
+	Gray88               = rgb(224, 224, 224)
+	Grey88               = rgb(224, 224, 224)
+	Gray89               = rgb(227, 227, 227)
+	Grey89               = rgb(227, 227, 227)
+	Gray90               = rgb(229, 229, 229)
+	Grey90               = rgb(229, 229, 229)
+	Gray91               = rgb(232, 232, 232)
+	Grey91               = rgb(232, 232, 232)
+	Gray92               = rgb(235, 235, 235)
+	Grey92               = rgb(235, 235, 235)
+	Gray93               = rgb(237, 237, 237)
+	Grey93               = rgb(237, 237, 237)
+	Gray94               = rgb(240, 240, 240)
+	Grey94               = rgb(240, 240, 240)
+	Gray95               = rgb(242, 242, 242)
+	Grey95               = rgb(242, 242, 242)
+	Gray96               = rgb(245, 245, 245)
+	Grey96               = rgb(245, 245, 245)
+	Gray97               = rgb(247, 247, 247)
+	Grey97               = rgb(247, 247, 247)
+	Gray98               = rgb(250, 250, 250)
+	Grey98               = rgb(250, 250, 250)
+	Gray99               = rgb(252, 252, 252)
+	Grey99               = rgb(252, 252, 252)
+	Gray100              = rgb(255, 255, 255)
+	Grey100              = rgb(255, 255, 255)
+	DarkGrey             = rgb(169, 169, 169)
+	DarkGray             = rgb(169, 169, 169)
+	DarkBlue             = rgb(0, 0, 139)
+	DarkCyan             = rgb(0, 139, 139)
+	DarkMagenta          = rgb(139, 0, 139)
+	DarkRed              = rgb(139, 0, 0)
+	LightGreen           = rgb(144, 238, 144)
+)
+
+// CSS colours missing from X11, and the CSS versions of the colours the two disagree on
+var (
+	Aqua          = rgb(0, 255, 255)
+	Crimson       = rgb(220, 20, 60)
+	Fuchsia       = rgb(255, 0, 255)
+	Indigo        = rgb(75, 0, 130)
+	Lime          = rgb(0, 255, 0)
+	Olive         = rgb(128, 128, 0)
+	RebeccaPurple = rgb(102, 51, 153)
+	Silver        = rgb(192, 192, 192)
+	Teal          = rgb(0, 128, 128)
+	WebGray       = rgb(128, 128, 128)
+	WebGrey       = rgb(128, 128, 128)
+	WebGreen      = rgb(0, 128, 0)
+	WebMaroon     = rgb(128, 0, 0)
+	WebPurple     = rgb(128, 0, 128)
+	X11Gray       = rgb(190, 190, 190)
+	X11Grey       = rgb(190, 190, 190)
+	X11Green      = rgb(0, 255, 0)
+	X11Maroon     = rgb(176, 48, 96)
+	X11Purple     = rgb(160, 32, 240)
+)
+
+// named - every colour by lower case name
+var named = map[string]GameEngine.Colour{
+	"snow":                 Snow,
+	"ghostwhite":           GhostWhite,
+	"whitesmoke":           WhiteSmoke,
+	"gainsboro":            Gainsboro,
+	"floralwhite":          FloralWhite,
+	"oldlace":              OldLace,
+	"linen":                Linen,
+	"antiquewhite":         AntiqueWhite,
+	"papayawhip":           PapayaWhip,
+	"blanchedalmond":       BlanchedAlmond,
+	"bisque":               Bisque,
+	"peachpuff":            PeachPuff,
+	"navajowhite":          NavajoWhite,
+	"moccasin":             Moccasin,
+	"cornsilk":             Cornsilk,
+	"ivory":                Ivory,
+	"lemonchiffon":         LemonChiffon,
+	"seashell":             Seashell,
+	"honeydew":             Honeydew,
+	"mintcream":            MintCream,
+	"azure":                Azure,
+	"aliceblue":            AliceBlue,
+	"lavender":             Lavender,
+	"lavenderblush":        LavenderBlush,
+	"mistyrose":            MistyRose,
+	"white":                White,
+	"black":                Black,
+	"darkslategray":        DarkSlateGray,
+	"darkslategrey":        DarkSlateGrey,
+	"dimgray":              DimGray,
+	"dimgrey":              DimGrey,
+	"slategray":            SlateGray,
+	"slategrey":            SlateGrey,
+	"lightslategray":       LightSlateGray,
+	"lightslategrey":       LightSlateGrey,
+	"gray":                 Gray,
+	"grey":                 Grey,
+	"lightgrey":            LightGrey,
+	"lightgray":            LightGray,
+	"midnightblue":         MidnightBlue,
+	"navy":                 Navy,
+	"navyblue":             NavyBlue,
+	"cornflowerblue":       CornflowerBlue,
+	"darkslateblue":        DarkSlateBlue,
+	"slateblue":            SlateBlue,
+	"mediumslateblue":      MediumSlateBlue,
+	"lightslateblue":       LightSlateBlue,
+	"mediumblue":           MediumBlue,
+	"royalblue":            RoyalBlue,
+	"blue":                 Blue,
+	"dodgerblue":           DodgerBlue,
+	"deepskyblue":          DeepSkyBlue,
+	"skyblue":              SkyBlue,
+	"lightskyblue":         LightSkyBlue,
+	"steelblue":            SteelBlue,
+	"lightsteelblue":       LightSteelBlue,
+	"lightblue":            LightBlue,
+	"powderblue":           PowderBlue,
+	"paleturquoise":        PaleTurquoise,
+	"darkturquoise":        DarkTurquoise,
+	"mediumturquoise":      MediumTurquoise,
+	"turquoise":            Turquoise,
+	"cyan":                 Cyan,
+	"lightcyan":            LightCyan,
+	"cadetblue":            CadetBlue,
+	"mediumaquamarine":     MediumAquamarine,
+	"aquamarine":           Aquamarine,
+	"darkgreen":            DarkGreen,
+	"darkolivegreen":       DarkOliveGreen,
+	"darkseagreen":         DarkSeaGreen,
+	"seagreen":             SeaGreen,
+	"mediumseagreen":       MediumSeaGreen,
+	"lightseagreen":        LightSeaGreen,
+	"palegreen":            PaleGreen,
+	"springgreen":          SpringGreen,
+	"lawngreen":            LawnGreen,
+	"green":                Green,
+	"chartreuse":           Chartreuse,
+	"mediumspringgreen":    MediumSpringGreen,
+	"greenyellow":          GreenYellow,
+	"limegreen":            LimeGreen,
+	"yellowgreen":          YellowGreen,
+	"forestgreen":          ForestGreen,
+	"olivedrab":            OliveDrab,
+	"darkkhaki":            DarkKhaki,
+	"khaki":                Khaki,
+	"palegoldenrod":        PaleGoldenrod,
+	"lightgoldenrodyellow": LightGoldenrodYellow,
+	"lightyellow":          LightYellow,
+	"yellow":               Yellow,
+	"gold":                 Gold,
+	"lightgoldenrod":       LightGoldenrod,
+	"goldenrod":            Goldenrod,
+	"darkgoldenrod":        DarkGoldenrod,
+	"rosybrown":            RosyBrown,
+	"indianred":            IndianRed,
+	"saddlebrown":          SaddleBrown,
+	"sienna":               Sienna,
+	"peru":                 Peru,
+	"burlywood":            Burlywood,
+	"beige":                Beige,
+	"wheat":                Wheat,
+	"sandybrown":           SandyBrown,
+	"tan":                  Tan,
+	"chocolate":            Chocolate,
+	"firebrick":            Firebrick,
+	"brown":                Brown,
+	"darksalmon":           DarkSalmon,
+	"salmon":               Salmon,
+	"lightsalmon":          LightSalmon,
+	"orange":               Orange,
+	"darkorange":           DarkOrange,
+	"coral":                Coral,
+	"lightcoral":           LightCoral,
+	"tomato":               Tomato,
+	"orangered":            OrangeRed,
+	"red":                  Red,
+	"hotpink":              HotPink,
+	"deeppink":             DeepPink,
+	"pink":                 Pink,
+	"lightpink":            LightPink,
+	"palevioletred":        PaleVioletRed,
+	"maroon":               Maroon,
+	"mediumvioletred":      MediumVioletRed,
+	"violetred":            VioletRed,
+	"magenta":              Magenta,
+	"violet":               Violet,
+	"plum":                 Plum,
+	"orchid":               Orchid,
+	"mediumorchid":         MediumOrchid,
+	"darkorchid":           DarkOrchid,
+	"darkviolet":           DarkViolet,
+	"blueviolet":           BlueViolet,
+	"purple":               Purple,
+	"mediumpurple":         MediumPurple,
+	"thistle":              Thistle,
+	"snow1":                Snow1,
+	"snow2":                Snow2,
+	"snow3":                Snow3,
+	"snow4":                Snow4,
+	"seashell1":            Seashell1,
+	"seashell2":            Seashell2,
+	"seashell3":            Seashell3,
+	"seashell4":            Seashell4,
+	"antiquewhite1":        AntiqueWhite1,
+	"antiquewhite2":        AntiqueWhite2,
+	"antiquewhite3":        AntiqueWhite3,
+	"antiquewhite4":        AntiqueWhite4,
+	"bisque1":              Bisque1,
+	"bisque2":              Bisque2,
+	"bisque3":              Bisque3,
+	"bisque4":              Bisque4,
+	"peachpuff1":           PeachPuff1,
+	"peachpuff2":           PeachPuff2,
+	"peachpuff3":           PeachPuff3,
+	"peachpuff4":           PeachPuff4,
+	"navajowhite1":         NavajoWhite1,
+	"navajowhite2":         NavajoWhite2,
+	"navajowhite3":         NavajoWhite3,
+	"navajowhite4":         NavajoWhite4,
+	"lemonchiffon1":        LemonChiffon1,
+	"lemonchiffon2":        LemonChiffon2,
+	"lemonchiffon3":        LemonChiffon3,
+	"lemonchiffon4":        LemonChiffon4,
+	"cornsilk1":            Cornsilk1,
+	"cornsilk2":            Cornsilk2,
+	"cornsilk3":            Cornsilk3,
+	"cornsilk4":            Cornsilk4,
+	"ivory1":               Ivory1,
+	"ivory2":               Ivory2,
+	"ivory3":               Ivory3,
+	"ivory4":               Ivory4,
+	"honeydew1":            Honeydew1,
+	"honeydew2":            Honeydew2,
+	"honeydew3":            Honeydew3,
+	"honeydew4":            Honeydew4,
+	"lavenderblush1":       LavenderBlush1,
+	"lavenderblush2":       LavenderBlush2,
+	"lavenderblush3":       LavenderBlush3,
+	"lavenderblush4":       LavenderBlush4,
+	"mistyrose1":           MistyRose1,
+	"mistyrose2":           MistyRose2,
+	"mistyrose3":           MistyRose3,
+	"mistyrose4":           MistyRose4,
+	"azure1":               Azure1,
+	"azure2":               Azure2,
+	"azure3":               Azure3,
+	"azure4":               Azure4,
+	"slateblue1":           SlateBlue1,
+	"slateblue2":           SlateBlue2,
+	"slateblue3":           SlateBlue3,
+	"slateblue4":           SlateBlue4,
+	"royalblue1":           RoyalBlue1,
+	"royalblue2":           RoyalBlue2,
+	"royalblue3":           RoyalBlue3,
+	"royalblue4":           RoyalBlue4,
+	"blue1":                Blue1,
+	"blue2":                Blue2,
+	"blue3":                Blue3,
+	"blue4":                Blue4,
+	"dodgerblue1":          DodgerBlue1,
+	"dodgerblue2":          DodgerBlue2,
+	"dodgerblue3":          DodgerBlue3,
+	"dodgerblue4":          DodgerBlue4,
+	"steelblue1":           SteelBlue1,
+	"steelblue2":           SteelBlue2,
+	"steelblue3":           SteelBlue3,
+	"steelblue4":           SteelBlue4,
+	"deepskyblue1":         DeepSkyBlue1,
+	"deepskyblue2":         DeepSkyBlue2,
+	"deepskyblue3":         DeepSkyBlue3,
+	"deepskyblue4":         DeepSkyBlue4,
+	"skyblue1":             SkyBlue1,
+	"skyblue2":             SkyBlue2,
+	"skyblue3":             SkyBlue3,
+	"skyblue4":             SkyBlue4,
+	"lightskyblue1":        LightSkyBlue1,
+	"lightskyblue2":        LightSkyBlue2,
+	"lightskyblue3":        LightSkyBlue3,
+	"lightskyblue4":        LightSkyBlue4,
+	"slategray1":           SlateGray1,
+	"slategray2":           SlateGray2,
+	"slategray3":           SlateGray3,
+	"slategray4":           SlateGray4,
+	"lightsteelblue1":      LightSteelBlue1,
+	"lightsteelblue2":      LightSteelBlue2,
+	"lightsteelblue3":      LightSteelBlue3,
+	"lightsteelblue4":      LightSteelBlue4,
+	"lightblue1":           LightBlue1,
+	"lightblue2":           LightBlue2,
+	"lightblue3":           LightBlue3,
+	"lightblue4":           LightBlue4,
+	"lightcyan1":           LightCyan1,
+	"lightcyan2":           LightCyan2,
+	"lightcyan3":           LightCyan3,
+	"lightcyan4":           LightCyan4,
+	"paleturquoise1":       PaleTurquoise1,
+	"paleturquoise2":       PaleTurquoise2,
+	"paleturquoise3":       PaleTurquoise3,
+	"paleturquoise4":       PaleTurquoise4,
+	"cadetblue1":           CadetBlue1,
+	"cadetblue2":           CadetBlue2,
+	"cadetblue3":           CadetBlue3,
+	"cadetblue4":           CadetBlue4,
+	"turquoise1":           Turquoise1,
+	"turquoise2":           Turquoise2,
+	"turquoise3":           Turquoise3,
+	"turquoise4":           Turquoise4,
+	"cyan1":                Cyan1,
+	"cyan2":                Cyan2,
+	"cyan3":                Cyan3,
+	"cyan4":                Cyan4,
+	"darkslategray1":       DarkSlateGray1,
+	"darkslategray2":       DarkSlateGray2,
+	"darkslategray3":       DarkSlateGray3,
+	"darkslategray4":       DarkSlateGray4,
+	"aquamarine1":          Aquamarine1,
+	"aquamarine2":          Aquamarine2,
+	"aquamarine3":          Aquamarine3,
+	"aquamarine4":          Aquamarine4,
+	"darkseagreen1":        DarkSeaGreen1,
+	"darkseagreen2":        DarkSeaGreen2,
+	"darkseagreen3":        DarkSeaGreen3,
+	"darkseagreen4":        DarkSeaGreen4,
+	"seagreen1":            SeaGreen1,
+	"seagreen2":            SeaGreen2,
+	"seagreen3":            SeaGreen3,
+	"seagreen4":            SeaGreen4,
+	"palegreen1":           PaleGreen1,
+	"palegreen2":           PaleGreen2,
+	"palegreen3":           PaleGreen3,
+	"palegreen4":           PaleGreen4,
+	"springgreen1":         SpringGreen1,
+	"springgreen2":         SpringGreen2,
+	"springgreen3":         SpringGreen3,
+	"springgreen4":         SpringGreen4,
+	"green1":               Green1,
+	"green2":               Green2,
+	"green3":               Green3,
+	"green4":               Green4,
+	"chartreuse1":          Chartreuse1,
+	"chartreuse2":          Chartreuse2,
+	"chartreuse3":          Chartreuse3,
+	"chartreuse4":          Chartreuse4,
+	"olivedrab1":           OliveDrab1,
+	"olivedrab2":           OliveDrab2,
+	"olivedrab3":           OliveDrab3,
+	"olivedrab4":           OliveDrab4,
+	"darkolivegreen1":      DarkOliveGreen1,
+	"darkolivegreen2":      DarkOliveGreen2,
+	"darkolivegreen3":      DarkOliveGreen3,
+	"darkolivegreen4":      DarkOliveGreen4,
+	"khaki1":               Khaki1,
+	"khaki2":               Khaki2,
+	"khaki3":               Khaki3,
+	"khaki4":               Khaki4,
+	"lightgoldenrod1":      LightGoldenrod1,
+	"lightgoldenrod2":      LightGoldenrod2,
+	"lightgoldenrod3":      LightGoldenrod3,
+	"lightgoldenrod4":      LightGoldenrod4,
+	"lightyellow1":         LightYellow1,
+	"lightyellow2":         LightYellow2,
+	"lightyellow3":         LightYellow3,
+	"lightyellow4":         LightYellow4,
+	"yellow1":              Yellow1,
+	"yellow2":              Yellow2,
+	"yellow3":              Yellow3,
+	"yellow4":              Yellow4,
+	"gold1":                Gold1,
+	"gold2":                Gold2,
+	"gold3":                Gold3,
+	"gold4":                Gold4,
+	"goldenrod1":           Goldenrod1,
+	"goldenrod2":           Goldenrod2,
+	"goldenrod3":           Goldenrod3,
+	"goldenrod4":           Goldenrod4,
+	"darkgoldenrod1":       DarkGoldenrod1,
+	"darkgoldenrod2":       DarkGoldenrod2,
+	"darkgoldenrod3":       DarkGoldenrod3,
+	"darkgoldenrod4":       DarkGoldenrod4,
+	"rosybrown1":           RosyBrown1,
+	"rosybrown2":           RosyBrown2,
+	"rosybrown3":           RosyBrown3,
+	"rosybrown4":           RosyBrown4,
+	"indianred1":           IndianRed1,
+	"indianred2":           IndianRed2,
+	"indianred3":           IndianRed3,
+	"indianred4":           IndianRed4,
+	"sienna1":              Sienna1,
+	"sienna2":              Sienna2,
+	"sienna3":              Sienna3,
+	"sienna4":              Sienna4,
+	"burlywood1":           Burlywood1,
+	"burlywood2":           Burlywood2,
+	"burlywood3":           Burlywood3,
+	"burlywood4":           Burlywood4,
+	"wheat1":               Wheat1,
+	"wheat2":               Wheat2,
+	"wheat3":               Wheat3,
+	"wheat4":               Wheat4,
+	"tan1":                 Tan1,
+	"tan2":                 Tan2,
+	"tan3":                 Tan3,
+	"tan4":                 Tan4,
+	"chocolate1":           Chocolate1,
+	"chocolate2":           Chocolate2,
+	"chocolate3":           Chocolate3,
+	"chocolate4":           Chocolate4,
+	"firebrick1":           Firebrick1,
+	"firebrick2":           Firebrick2,
+	"firebrick3":           Firebrick3,
+	"firebrick4":           Firebrick4,
+	"brown1":               Brown1,
+	"brown2":               Brown2,
+	"brown3":               Brown3,
+	"brown4":               Brown4,
+	"salmon1":              Salmon1,
+	"salmon2":              Salmon2,
+	"salmon3":              Salmon3,
+	"salmon4":              Salmon4,
+	"lightsalmon1":         LightSalmon1,
+	"lightsalmon2":         LightSalmon2,
+	"lightsalmon3":         LightSalmon3,
+	"lightsalmon4":         LightSalmon4,
+	"orange1":              Orange1,
+	"orange2":              Orange2,
+	"orange3":              Orange3,
+	"orange4":              Orange4,
+	"darkorange1":          DarkOrange1,
+	"darkorange2":          DarkOrange2,
+	"darkorange3":          DarkOrange3,
+	"darkorange4":          DarkOrange4,
+	"coral1":               Coral1,
+	"coral2":               Coral2,
+	"coral3":               Coral3,
+	"coral4":               Coral4,
+	"tomato1":              Tomato1,
+	"tomato2":              Tomato2,
+	"tomato3":              Tomato3,
+	"tomato4":              Tomato4,
+	"orangered1":           OrangeRed1,
+	"orangered2":           OrangeRed2,
+	"orangered3":           OrangeRed3,
+	"orangered4":           OrangeRed4,
+	"red1":                 Red1,
+	"red2":                 Red2,
+	"red3":                 Red3,
+	"red4":                 Red4,
+	"debianred":            DebianRed,
+	"deeppink1":            DeepPink1,
+	"deeppink2":            DeepPink2,
+	"deeppink3":            DeepPink3,
+	"deeppink4":            DeepPink4,
+	"hotpink1":             HotPink1,
+	"hotpink2":             HotPink2,
+	"hotpink3":             HotPink3,
+	"hotpink4":             HotPink4,
+	"pink1":                Pink1,
+	"pink2":                Pink2,
+	"pink3":                Pink3,
+	"pink4":                Pink4,
+	"lightpink1":           LightPink1,
+	"lightpink2":           LightPink2,
+	"lightpink3":           LightPink3,
+	"lightpink4":           LightPink4,
+	"palevioletred1":       PaleVioletRed1,
+	"palevioletred2":       PaleVioletRed2,
+	"palevioletred3":       PaleVioletRed3,
+	"palevioletred4":       PaleVioletRed4,
+	"maroon1":              Maroon1,
+	"maroon2":              Maroon2,
+	"maroon3":              Maroon3,
+	"maroon4":              Maroon4,
+	"violetred1":           VioletRed1,
+	"violetred2":           VioletRed2,
+	"violetred3":           VioletRed3,
+	"violetred4":           VioletRed4,
+	"magenta1":             Magenta1,
+	"magenta2":             Magenta2,
+	"magenta3":             Magenta3,
+	"magenta4":             Magenta4,
+	"orchid1":              Orchid1,
+	"orchid2":              Orchid2,
+	"orchid3":              Orchid3,
+	"orchid4":              Orchid4,
+	"plum1":                Plum1,
+	"plum2":                Plum2,
+	"plum3":                Plum3,
+	"plum4":                Plum4,
+	"mediumorchid1":        MediumOrchid1,
+	"mediumorchid2":        MediumOrchid2,
+	"mediumorchid3":        MediumOrchid3,
+	"mediumorchid4":        MediumOrchid4,
+	"darkorchid1":          DarkOrchid1,
+	"darkorchid2":          DarkOrchid2,
+	"darkorchid3":          DarkOrchid3,
+	"darkorchid4":          DarkOrchid4,
+	"purple1":              Purple1,
+	"purple2":              Purple2,
+	"purple3":              Purple3,
+	"purple4":              Purple4,
+	"mediumpurple1":        MediumPurple1,
+	"mediumpurple2":        MediumPurple2,
+	"mediumpurple3":        MediumPurple3,
+	"mediumpurple4":        MediumPurple4,
+	"thistle1":             Thistle1,
+	"thistle2":             Thistle2,
+	"thistle3":             Thistle3,
+	"thistle4":             Thistle4,
+	"gray0":                Gray0,
+	"grey0":                Grey0,
+	"gray1":                Gray1,
+	"grey1":                Grey1,
+	"gray2":                Gray2,
+	"grey2":                Grey2,
+	"gray3":                Gray3,
+	"grey3":                Grey3,
+	"gray4":                Gray4,
+	"grey4":                Grey4,
+	"gray5":                Gray5,
+	"grey5":                Grey5,
+	"gray6":                Gray6,
+	"grey6":                Grey6,
+	"gray7":                Gray7,
+	"grey7":                Grey7,
+	"gray8":                Gray8,
+	"grey8":                Grey8,
+	"gray9":                Gray9,
+	"grey9":                Grey9,
+	"gray10":               Gray10,
+	"grey10":               Grey10,
+	"gray11":               Gray11,
+	"grey11":               Grey11,
+	"gray12":               Gray12,
+	"grey12":               Grey12,
+	"gray13":               Gray13,
+	"grey13":               Grey13,
+	"gray14":               Gray14,
+	"grey14":               Grey14,
+	"gray15":               Gray15,
+	"grey15":               Grey15,
+	"gray16":               Gray16,
+	"grey16":               Grey16,
+	"gray17":               Gray17,
+	"grey17":               Grey17,
+	"gray18":               Gray18,
+	"grey18":               Grey18,
+	"gray19":               Gray19,
+	"grey19":               Grey19,
+	"gray20":               Gray20,
+	"grey20":               Grey20,
+	"gray21":               Gray21,
+	"grey21":               Grey21,
+	"gray22":               Gray22,
+	"grey22":               Grey22,
+	"gray23":               Gray23,
+	"grey23":               Grey23,
+	"gray24":               Gray24,
+	"grey24":               Grey24,
+	"gray25":               Gray25,
+	"grey25":               Grey25,
+	"gray26":               Gray26,
+	"grey26":               Grey26,
+	"gray27":               Gray27,
+	"grey27":               Grey27,
+	"gray28":               Gray28,
+	"grey28":               Grey28,
+	"gray29":               Gray29,
+	"grey29":               Grey29,
+	"gray30":               Gray30,
+	"grey30":               Grey30,
+	"gray31":               Gray31,
+	"grey31":               Grey31,
+	"gray32":               Gray32,
+	"grey32":               Grey32,
+	"gray33":               Gray33,
+	"grey33":               Grey33,
+	"gray34":               Gray34,
+	"grey34":               Grey34,
+	"gray35":               Gray35,
+	"grey35":               Grey35,
+	"gray36":               Gray36,
+	"grey36":               Grey36,
+	"gray37":               Gray37,
+	"grey37":               Grey37,
+	"gray38":               Gray38,
+	"grey38":               Grey38,
+	"gray39":               Gray39,
+	"grey39":               Grey39,
+	"gray40":               Gray40,
+	"grey40":               Grey40,
+	"gray41":               Gray41,
+	"grey41":               Grey41,
+	"gray42":               Gray42,
+	"grey42":               Grey42,
+	"gray43":               Gray43,
+	"grey43":               Grey43,
+	"gray44":               Gray44,
+	"grey44":               Grey44,
+	"gray45":               Gray45,
+	"grey45":               Grey45,
+	"gray46":               Gray46,
+	"grey46":               Grey46,
+	"gray47":               Gray47,
+	"grey47":               Grey47,
+	"gray48":               Gray48,
+	"grey48":               Grey48,
+	"gray49":               Gray49,
+	"grey49":               Grey49,
+	"gray50":               Gray50,
+	"grey50":               Grey50,
+	"gray51":               Gray51,
+	"grey51":               Grey51,
+	"gray52":               Gray52,
+	"grey52":               Grey52,
+	"gray53":               Gray53,
+	"grey53":               Grey53,
+	"gray54":               Gray54,
+	"grey54":               Grey54,
+	"gray55":               Gray55,
+	"grey55":               Grey55,
+	"gray56":               Gray56,
+	"grey56":               Grey56,
+	"gray57":               Gray57,
+	"grey57":               Grey57,
+	"gray58":               Gray58,
+	"grey58":               Grey58,
+	"gray59":               Gray59,
+	"grey59":               Grey59,
+	"gray60":               Gray60,
+	"grey60":               Grey60,
+	"gray61":               Gray61,
+	"grey61":               Grey61,
+	"gray62":               Gray62,
+	"grey62":               Grey62,
+	"gray63":               Gray63,
+	"grey63":               Grey63,
+	"gray64":               Gray64,
+	"grey64":               Grey64,
+	"gray65":               Gray65,
+	"grey65":               Grey65,
+	"gray66":               Gray66,
+	"grey66":               Grey66,
+	"gray67":               Gray67,
+	"grey67":               Grey67,
+	"gray68":               Gray68,
+	"grey68":               Grey68,
+	"gray69":               Gray69,
+	"grey69":               Grey69,
+	"gray70":               Gray70,
+	"grey70":               Grey70,
+	"gray71":               Gray71,
+	"grey71":               Grey71,
+	"gray72":               Gray72,
+	"grey72":               Grey72,
+	"gray73":               Gray73,
+	"grey73":               Grey73,
+	"gray74":               Gray74,
+	"grey74":               Grey74,
+	"gray75":               Gray75,
+	"grey75":               Grey75,
+	"gray76":               Gray76,
+	"grey76":               Grey76,
+	"gray77":               Gray77,
+	"grey77":               Grey77,
+	"gray78":               Gray78,
+	"grey78":               Grey78,
+	"gray79":               Gray79,
+	"grey79":               Grey79,
+	"gray80":               Gray80,
+	"grey80":               Grey80,
+	"gray81":               Gray81,
+	"grey81":               Grey81,
+	"gray82":               Gray82,
+	"grey82":               Grey82,
+	"gray83":               Gray83,
+	"grey83":               Grey83,
+	"gray84":               Gray84,
+	"grey84":               Grey84,
+	"gray85":               Gray85,
+	"grey85":               Grey85,
+	"gray86":               Gray86,
+	"grey86":               Grey86,
+	"gray87":               Gray87,
+	"grey87":               Grey87,
+	"gray88":               Gray88,
+	"grey88":               Grey88,
+	"gray89":               Gray89,
+	"grey89":               Grey89,
+	"gray90":               Gray90,
+	"grey90":               Grey90,
+	"gray91":               Gray91,
+	"grey91":               Grey91,
+	"gray92":               Gray92,
+	"grey92":               Grey92,
+	"gray93":               Gray93,
+	"grey93":               Grey93,
+	"gray94":               Gray94,
+	"grey94":               Grey94,
+	"gray95":               Gray95,
+	"grey95":               Grey95,
+	"gray96":               Gray96,
+	"grey96":               Grey96,
+	"gray97":               Gray97,
+	"grey97":               Grey97,
+	"gray98":               Gray98,
+	"grey98":               Grey98,
+	"gray99":               Gray99,
+	"grey99":               Grey99,
+	"gray100":              Gray100,
+	"grey100":              Grey100,
+	"darkgrey":             DarkGrey,
+	"darkgray":             DarkGray,
+	"darkblue":             DarkBlue,
+	"darkcyan":             DarkCyan,
+	"darkmagenta":          DarkMagenta,
+	"darkred":              DarkRed,
+	"lightgreen":           LightGreen,
+	"aqua":                 Aqua,
+	"crimson":              Crimson,
+	"fuchsia":              Fuchsia,
+	"indigo":               Indigo,
+	"lime":                 Lime,
+	"olive":                Olive,
+	"rebeccapurple":        RebeccaPurple,
+	"silver":               Silver,
+	"teal":                 Teal,
+	"webgray":              WebGray,
+	"webgrey":              WebGrey,
+	"webgreen":             WebGreen,
+	"webmaroon":            WebMaroon,
+	"webpurple":            WebPurple,
+	"x11gray":              X11Gray,
+	"x11grey":              X11Grey,
+	"x11green":             X11Green,
+	"x11maroon":            X11Maroon,
+	"x11purple":            X11Purple,
+}
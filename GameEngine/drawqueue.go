@@ -0,0 +1,57 @@
+package GameEngine
+
+import "sort"
+
+// drawCommand - a deferred draw waiting for Flush
+type drawCommand struct {
+	layer  int
+	key    float64
+	camera *Camera // camera set when the draw was queued
+	draw   func(c *Context)
+}
+
+// Defer - queues draw to run at the next Flush (Present flushes) instead of now. Queued draws run in order of
+// layer, lowest first, then by key within a layer (e.g. pass y to draw things lower down the screen in front),
+// then in the order they were queued. Each runs through the camera that was set when it was queued, unless its
+// layer is a screen layer. draw should set its own colour
+func (c *Context) Defer(layer int, key float64, draw func(c *Context)) {
+	c.queue = append(c.queue, drawCommand{layer: layer, key: key, camera: c.camera, draw: draw})
+}
+
+// SetScreenLayer - makes deferred draws on layer ignore the camera and draw straight to the screen, e.g. for a HUD
+func (c *Context) SetScreenLayer(layer int, screen bool) {
+	if c.screenLayers == nil {
+		c.screenLayers = map[int]bool{}
+	}
+	c.screenLayers[layer] = screen
+}
+
+// Flush - runs the queued draws now, in order, and empties the queue
+func (c *Context) Flush() {
+	if len(c.queue) == 0 {
+		return
+	}
+	// anything queued while flushing waits for the next flush
+	q := c.queue
+	c.queue = nil
+	sort.SliceStable(q, func(i, j int) bool {
+		if q[i].layer != q[j].layer {
+			return q[i].layer < q[j].layer
+		}
+		return q[i].key < q[j].key
+	})
+	cam := c.camera
+	for i := range q {
+		if c.screenLayers[q[i].layer] {
+			c.camera = nil
+		} else {
+			c.camera = q[i].camera
+		}
+		q[i].draw(c)
+		q[i] = drawCommand{}
+	}
+	c.camera = cam
+	if c.queue == nil {
+		c.queue = q[:0]
+	}
+}
@@ -0,0 +1,197 @@
+package ecs
+
+import (
+	"strings"
+	"testing"
+)
+
+type position struct{ X, Y float64 }
+type velocity struct{ X, Y float64 }
+type dead struct{}
+
+// moving - a world of n entities with a position (X is their number) and a velocity
+func moving(n int) (w *World, es []Entity) {
+	w = NewWorld()
+	for i := 0; i < n; i++ {
+		e := w.Create()
+		Add(w, e, position{X: float64(i)})
+		Add(w, e, velocity{X: 1})
+		es = append(es, e)
+	}
+	return
+}
+
+func TestChangesWaitForQuery(t *testing.T) {
+	w, es := moving(3)
+	var made Entity
+	visited := 0
+	Each2(w, func(e Entity, p *position, v *velocity) {
+		visited++
+		if visited == 1 {
+			w.Destroy(es[0])
+			Add(w, es[1], dead{})
+			Remove[velocity](w, es[2])
+			made = w.Create()
+			Add(w, made, position{X: 9})
+			Add(w, made, velocity{})
+		}
+		if !w.Alive(es[0]) || Has[dead](w, es[1]) || !Has[velocity](w, es[2]) || Has[position](w, made) {
+			t.Errorf("change made during the query")
+		}
+		if !w.Iterating() {
+			t.Errorf("not iterating in a query")
+		}
+	})
+	if visited != 3 {
+		t.Errorf("visited %d", visited)
+	}
+	if w.Iterating() {
+		t.Errorf("still iterating")
+	}
+	if w.Alive(es[0]) || Has[position](w, es[0]) || !Has[dead](w, es[1]) || Has[velocity](w, es[2]) {
+		t.Errorf("changes not made after the query")
+	}
+	if p, ok := Get[position](w, made); !ok || p.X != 9 {
+		t.Errorf("new entity got %v, %v", p, ok)
+	}
+	if w.Len() != 3 || Count[position](w) != 3 || Count[velocity](w) != 2 {
+		t.Errorf("got %d entities, %d positions, %d velocities", w.Len(), Count[position](w), Count[velocity](w))
+	}
+}
+
+func TestNestedQueries(t *testing.T) {
+	w, es := moving(3)
+	pairs := 0
+	Each(w, func(a Entity, _ *position) {
+		Each(w, func(b Entity, _ *velocity) {
+			pairs++
+			if a != b {
+				w.Destroy(b)
+			}
+		})
+		// the inner query has ended but the outer hasn't, so nothing is gone yet
+		for _, e := range es {
+			if !w.Alive(e) {
+				t.Errorf("%v destroyed inside the outer query", e)
+			}
+		}
+	})
+	if pairs != 9 {
+		t.Errorf("visited %d pairs", pairs)
+	}
+	if w.Len() != 0 || Count[position](w) != 0 {
+		t.Errorf("%d left after the outer query", w.Len())
+	}
+}
+
+func TestStaleEntity(t *testing.T) {
+	w := NewWorld()
+	old := w.Create()
+	Add(w, old, position{X: 1})
+	w.Destroy(old)
+	e := w.Create()
+	if e.Index() != old.Index() || e == old || e.Generation() != old.Generation()+1 {
+		t.Fatalf("slot not reused: %v then %v", old, e)
+	}
+	Add(w, e, position{X: 2})
+	if w.Alive(old) || !w.Alive(e) {
+		t.Errorf("alive: old %v, new %v", w.Alive(old), w.Alive(e))
+	}
+	if _, ok := Get[position](w, old); ok || Has[position](w, old) {
+		t.Errorf("old entity sees the new one's component")
+	}
+	// changes through an old entity do nothing
+	Add(w, old, position{X: 3})
+	Remove[position](w, old)
+	w.Destroy(old)
+	if p, ok := Get[position](w, e); !w.Alive(e) || !ok || p.X != 2 {
+		t.Errorf("new entity changed through the old: %v, %v", p, ok)
+	}
+	if w.Create() == Nil || old == Nil {
+		t.Errorf("Nil given out")
+	}
+}
+
+func TestSwapRemove(t *testing.T) {
+	w, es := moving(6)
+	s := storeOf[position](w)
+	check := func() {
+		t.Helper()
+		for i, e := range s.dense {
+			if int(s.sparse[e.Index()]) != i {
+				t.Errorf("%v at %d, sparse says %d", e, i, s.sparse[e.Index()])
+			}
+		}
+		for n, e := range es {
+			p, ok := Get[position](w, e)
+			if ok != w.Alive(e) || ok && p.X != float64(n) {
+				t.Errorf("entity %d: got %v, %v", n, p, ok)
+			}
+		}
+	}
+	// the first, one from the middle and the last
+	for _, n := range []int{0, 3, 5} {
+		w.Destroy(es[n])
+		check()
+		if s.sparse[es[n].Index()] != -1 {
+			t.Errorf("entity %d still in sparse", n)
+		}
+	}
+	if len(s.dense) != 3 || len(s.data) != 3 {
+		t.Errorf("%d left", len(s.dense))
+	}
+}
+
+func TestClearInQuery(t *testing.T) {
+	w, es := moving(4)
+	visited := 0
+	Each(w, func(e Entity, _ *position) {
+		if visited == 0 {
+			w.Clear()
+		}
+		visited++
+	})
+	if visited != 4 {
+		t.Errorf("visited %d", visited)
+	}
+	if w.Len() != 0 || Count[position](w) != 0 || Count[velocity](w) != 0 {
+		t.Errorf("%d entities, %d positions left", w.Len(), Count[position](w))
+	}
+	for _, e := range es {
+		if w.Alive(e) {
+			t.Errorf("%v alive", e)
+		}
+	}
+	Each(w, func(e Entity, _ *position) {
+		t.Errorf("visited %v after clear", e)
+	})
+	if e := w.Create(); !w.Alive(e) || w.Len() != 1 {
+		t.Errorf("can't create after clear")
+	}
+}
+
+func TestSystemOrder(t *testing.T) {
+	w := NewWorld()
+	var ran []string
+	add := func(name string, order int) {
+		w.AddSystem(name, order, SystemFunc(func(w *World, elapsed float64) {
+			ran = append(ran, name)
+		}))
+	}
+	add("a", 1)
+	add("b", 0)
+	add("c", 1)
+	add("d", 0)
+	add("e", -1)
+	w.Update(1)
+	if got := strings.Join(ran, ""); got != "ebdac" {
+		t.Errorf("ran %s", got)
+	}
+	ran = nil
+	w.RemoveSystem("a")
+	add("f", 0)
+	w.Update(1)
+	if got := strings.Join(ran, ""); got != "ebdfc" {
+		t.Errorf("after remove ran %s", got)
+	}
+}
@@ -0,0 +1,77 @@
+package ecs
+
+// Each - calls fn for every entity with a component of type A, with a pointer to change it through. Adding,
+// removing and destroying in fn is put off until Each returns (see World.Iterating), so everything is visited
+// once, as it was when Each started
+func Each[A any](w *World, fn func(e Entity, a *A)) {
+	sa := storeOf[A](w)
+	w.begin()
+	defer w.end()
+	for i := range sa.dense {
+		fn(sa.dense[i], &sa.data[i])
+	}
+}
+
+// Each2 - like Each for entities with both an A and a B
+func Each2[A, B any](w *World, fn func(e Entity, a *A, b *B)) {
+	sa, sb := storeOf[A](w), storeOf[B](w)
+	w.begin()
+	defer w.end()
+	// go through the shorter list, looking up in the other
+	if len(sb.dense) < len(sa.dense) {
+		for i, e := range sb.dense {
+			if pa := sa.find(e); pa >= 0 {
+				fn(e, &sa.data[pa], &sb.data[i])
+			}
+		}
+		return
+	}
+	for i, e := range sa.dense {
+		if pb := sb.find(e); pb >= 0 {
+			fn(e, &sa.data[i], &sb.data[pb])
+		}
+	}
+}
+
+// Each3 - like Each for entities with an A, a B and a C
+func Each3[A, B, C any](w *World, fn func(e Entity, a *A, b *B, c *C)) {
+	sa, sb, sc := storeOf[A](w), storeOf[B](w), storeOf[C](w)
+	w.begin()
+	defer w.end()
+	for _, e := range shortest(sa.dense, sb.dense, sc.dense) {
+		pa, pb, pc := sa.find(e), sb.find(e), sc.find(e)
+		if pa >= 0 && pb >= 0 && pc >= 0 {
+			fn(e, &sa.data[pa], &sb.data[pb], &sc.data[pc])
+		}
+	}
+}
+
+// Each4 - like Each for entities with an A, a B, a C and a D
+func Each4[A, B, C, D any](w *World, fn func(e Entity, a *A, b *B, c *C, d *D)) {
+	sa, sb, sc, sd := storeOf[A](w), storeOf[B](w), storeOf[C](w), storeOf[D](w)
+	w.begin()
+	defer w.end()
+	for _, e := range shortest(sa.dense, sb.dense, sc.dense, sd.dense) {
+		pa, pb, pc, pd := sa.find(e), sb.find(e), sc.find(e), sd.find(e)
+		if pa >= 0 && pb >= 0 && pc >= 0 && pd >= 0 {
+			fn(e, &sa.data[pa], &sb.data[pb], &sc.data[pc], &sd.data[pd])
+		}
+	}
+}
+
+// Without - true if e has no component of type T. For leaving things out in a query, e.g.
+// if ecs.Without[Dead](w, e) {...}
+func Without[T any](w *World, e Entity) bool {
+	return !Has[T](w, e)
+}
+
+// shortest - the shortest of lists
+func shortest(lists ...[]Entity) []Entity {
+	best := lists[0]
+	for _, l := range lists[1:] {
+		if len(l) < len(best) {
+			best = l
+		}
+	}
+	return best
+}
@@ -0,0 +1,106 @@
+package ecs
+
+// store - what the World needs of component storage whatever its type
+type store interface {
+	remove(e Entity)
+}
+
+// storage - components of type T, in a sparse set: data is packed together for fast queries and sparse finds
+// where an entity's component is in it from the entity's slot
+type storage[T any] struct {
+	sparse []int32 // by entity slot, position in dense and data, or -1
+	dense  []Entity
+	data   []T
+}
+
+// storeOf - the storage for T in w, made the first time it is asked for
+func storeOf[T any](w *World) *storage[T] {
+	// a nil *T is a different key for each type
+	key := (*T)(nil)
+	if s, ok := w.stores[key]; ok {
+		return s.(*storage[T])
+	}
+	s := &storage[T]{}
+	w.stores[key] = s
+	return s
+}
+
+// find - position of e's component, or -1
+func (s *storage[T]) find(e Entity) int {
+	i := int(e.Index())
+	if i >= len(s.sparse) {
+		return -1
+	}
+	p := s.sparse[i]
+	if p < 0 || s.dense[p] != e {
+		return -1
+	}
+	return int(p)
+}
+
+// set - gives e component v, replacing one it already has
+func (s *storage[T]) set(e Entity, v T) {
+	if p := s.find(e); p >= 0 {
+		s.data[p] = v
+		return
+	}
+	i := int(e.Index())
+	for len(s.sparse) <= i {
+		s.sparse = append(s.sparse, -1)
+	}
+	s.sparse[i] = int32(len(s.dense))
+	s.dense = append(s.dense, e)
+	s.data = append(s.data, v)
+}
+
+// remove - takes e's component out, moving the last one into its place
+func (s *storage[T]) remove(e Entity) {
+	p := s.find(e)
+	if p < 0 {
+		return
+	}
+	last := len(s.dense) - 1
+	s.dense[p], s.data[p] = s.dense[last], s.data[last]
+	s.sparse[s.dense[p].Index()] = int32(p)
+	s.sparse[e.Index()] = -1
+	var zero T
+	s.data[last] = zero
+	s.dense, s.data = s.dense[:last], s.data[:last]
+}
+
+// Add - gives e a component of type T, replacing any it has. Does nothing if e isn't alive. During a query it is
+// put off until the query ends
+func Add[T any](w *World, e Entity, v T) {
+	w.later(func() {
+		if w.Alive(e) {
+			storeOf[T](w).set(e, v)
+		}
+	})
+}
+
+// Remove - takes e's component of type T away
+func Remove[T any](w *World, e Entity) {
+	w.later(func() {
+		storeOf[T](w).remove(e)
+	})
+}
+
+// Get - e's component of type T, to read or change, and true, or nil and false if it hasn't got one. The pointer
+// is good until the next component of type T is added or removed
+func Get[T any](w *World, e Entity) (*T, bool) {
+	s := storeOf[T](w)
+	if p := s.find(e); p >= 0 {
+		return &s.data[p], true
+	}
+	return nil, false
+}
+
+// Has - true if e has a component of type T
+func Has[T any](w *World, e Entity) bool {
+	return storeOf[T](w).find(e) >= 0
+}
+
+// Count - how many entities have a component of type T
+func Count[T any](w *World) int {
+	return len(storeOf[T](w).dense)
+}
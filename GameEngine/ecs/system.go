@@ -0,0 +1,51 @@
+package ecs
+
+import "sort"
+
+// System - runs over the world every Update, e.g. moving everything with a position and velocity. elapsed is as
+// passed to Update, usually Context.Elapsed
+type System interface {
+	Update(w *World, elapsed float64)
+}
+
+// SystemFunc - a function used as a System
+type SystemFunc func(w *World, elapsed float64)
+
+// Update - System
+func (f SystemFunc) Update(w *World, elapsed float64) {
+	f(w, elapsed)
+}
+
+// system - a System with the name and order it was added with
+type system struct {
+	name  string
+	order int
+	s     System
+}
+
+// AddSystem - adds s to run in Update. Systems run lowest order first; ones with the same order in the order
+// they were added. name is for RemoveSystem
+func (w *World) AddSystem(name string, order int, s System) {
+	w.systems = append(w.systems, system{name: name, order: order, s: s})
+	sort.SliceStable(w.systems, func(i, j int) bool {
+		return w.systems[i].order < w.systems[j].order
+	})
+}
+
+// RemoveSystem - takes out the systems called name
+func (w *World) RemoveSystem(name string) {
+	kept := w.systems[:0]
+	for _, s := range w.systems {
+		if s.name != name {
+			kept = append(kept, s)
+		}
+	}
+	w.systems = kept
+}
+
+// Update - runs every system in order. What each system adds or removes in its queries is there for the next
+func (w *World) Update(elapsed float64) {
+	for _, s := range append([]system{}, w.systems...) {
+		s.s.Update(w, elapsed)
+	}
+}
@@ -0,0 +1,138 @@
+// Package ecs - entity component system for GameEngine. Things in a game are Entities, plain ids, with data
+// (components, any Go type) stored by type and looked up by entity. Systems run in order each frame and use
+// queries (Each, Each2...) to visit every entity with a set of components. Adding and removing while a query is
+// running is put off until it ends, so it is always safe
+package ecs
+
+// Entity - id of a thing in a World: a slot and a generation, which goes up each time the slot is reused so an old
+// Entity of something destroyed never matches the new thing in its slot. The zero Entity is never used
+type Entity uint64
+
+// Nil - the zero Entity, for "no entity"
+const Nil Entity = 0
+
+// newEntity - the Entity for slot index at generation gen
+func newEntity(index, gen uint32) Entity {
+	return Entity(uint64(gen)<<32 | uint64(index))
+}
+
+// Index - the slot of e in its World
+func (e Entity) Index() uint32 {
+	return uint32(e)
+}
+
+// Generation - how many times the slot of e has been used
+func (e Entity) Generation() uint32 {
+	return uint32(e >> 32)
+}
+
+// World - the entities, their components and the systems that run over them
+type World struct {
+	gens    []uint32 // generation of the entity in each slot
+	alive   []bool
+	free    []uint32 // slots of destroyed entities, to be reused
+	count   int
+	stores  map[any]store // component storage by type, see storeOf
+	systems []system
+	// queries running, and changes to make when the last of them ends
+	iterating int
+	pending   []func()
+}
+
+// NewWorld - an empty world
+func NewWorld() *World {
+	return &World{stores: map[any]store{}}
+}
+
+// Create - a new entity, with no components. Safe during a query, but components added to it only show up in
+// queries once the query ends
+func (w *World) Create() Entity {
+	w.count++
+	if n := len(w.free); n > 0 {
+		i := w.free[n-1]
+		w.free = w.free[:n-1]
+		w.alive[i] = true
+		return newEntity(i, w.gens[i])
+	}
+	// generations start at 1 so no entity is Nil
+	w.gens = append(w.gens, 1)
+	w.alive = append(w.alive, true)
+	return newEntity(uint32(len(w.gens)-1), 1)
+}
+
+// Alive - true if e has been created and not destroyed. An entity destroyed during a query is alive until it ends
+func (w *World) Alive(e Entity) bool {
+	i := e.Index()
+	return int(i) < len(w.gens) && w.alive[i] && w.gens[i] == e.Generation()
+}
+
+// Len - number of entities alive
+func (w *World) Len() int {
+	return w.count
+}
+
+// Destroy - removes e and all its components. Destroying an entity that isn't alive does nothing
+func (w *World) Destroy(e Entity) {
+	w.later(func() {
+		if !w.Alive(e) {
+			return
+		}
+		for _, s := range w.stores {
+			s.remove(e)
+		}
+		i := e.Index()
+		w.alive[i] = false
+		w.gens[i]++
+		if w.gens[i] == 0 {
+			// wrapped round, never give out generation 0
+			w.gens[i] = 1
+		}
+		w.free = append(w.free, i)
+		w.count--
+	})
+}
+
+// Clear - destroys every entity. Systems are kept
+func (w *World) Clear() {
+	w.later(func() {
+		for i, alive := range w.alive {
+			if alive {
+				w.Destroy(newEntity(uint32(i), w.gens[i]))
+			}
+		}
+	})
+}
+
+// Iterating - true while a query is running, when changes are put off until it ends
+func (w *World) Iterating() bool {
+	return w.iterating > 0
+}
+
+// later - does fn now, or when the queries running have all ended
+func (w *World) later(fn func()) {
+	if w.iterating > 0 {
+		w.pending = append(w.pending, fn)
+		return
+	}
+	fn()
+}
+
+// begin - a query starts
+func (w *World) begin() {
+	w.iterating++
+}
+
+// end - a query ends. The last to end makes the changes put off while they ran, in the order they were made
+func (w *World) end() {
+	w.iterating--
+	if w.iterating > 0 {
+		return
+	}
+	for len(w.pending) > 0 {
+		todo := w.pending
+		w.pending = nil
+		for _, fn := range todo {
+			fn()
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package GameEngine
+
+import "math"
+
+// indexedFrame - the frame buffer while in indexed colour, one palette index per block
+type indexedFrame struct {
+	w, h    int
+	pix     []uint8
+	pal     []Colour
+	cycles  []*PaletteCycle
+	fadeCol Colour
+	fade    float64
+	near    map[[3]uint8]uint8 // palette index already found for a draw colour
+}
+
+// maxNear - most draw colours remembered before starting again. Gradients, fog and anti-aliasing make new colours
+// all the time
+const maxNear = 4096
+
+// PaletteCycle - turns palette entries First to Last (inclusive) round like a wheel, Rate entries per Elapsed unit,
+// so whatever is drawn with them seems to move (waterfalls, lava, conveyor belts). Negative Rate goes the other way
+type PaletteCycle struct {
+	First, Last int
+	Rate        float64
+	pos         float64
+}
+
+// SetIndexed - switches to indexed colour: every block holds an index into pal (up to 256 colours) and is only
+// turned into a colour at Present, so changing the palette changes everything already drawn. Draw colours are
+// snapped to the nearest palette colour, or use SetDrawIndex. Blend modes are ignored. nil goes back to full colour
+func (c *Context) SetIndexed(pal []Colour) {
+	if len(pal) == 0 {
+		c.indexed = nil
+		c.updateFrame()
+		return
+	}
+	w, h := int(c.ScrnWidth), int(c.ScrnHeight)
+	c.indexed = &indexedFrame{w: w, h: h, pix: make([]uint8, w*h)}
+	c.SetPalette(pal)
+	c.updateFrame()
+	c.SetDrawIndex(0)
+}
+
+// Indexed - true in indexed colour
+func (c *Context) Indexed() bool {
+	return c.indexed != nil
+}
+
+// SetPalette - changes the palette used in indexed colour, which recolours everything drawn. Only the first 256
+// colours are used
+func (c *Context) SetPalette(pal []Colour) {
+	if c.indexed == nil {
+		return
+	}
+	if len(pal) > 256 {
+		pal = pal[:256]
+	}
+	c.indexed.pal = append([]Colour(nil), pal...)
+	c.indexed.near = map[[3]uint8]uint8{}
+}
+
+// Palette - the palette set in indexed colour (before any cycling or fade), nil in full colour
+func (c *Context) Palette() []Colour {
+	if c.indexed == nil {
+		return nil
+	}
+	return append([]Colour(nil), c.indexed.pal...)
+}
+
+// SetPaletteColour - changes palette entry i. Outside the palette is ignored
+func (c *Context) SetPaletteColour(i int, col Colour) {
+	if c.indexed == nil || i < 0 || i >= len(c.indexed.pal) {
+		return
+	}
+	c.indexed.pal[i] = col
+	c.indexed.near = map[[3]uint8]uint8{}
+}
+
+// SetDrawIndex - draws with palette entry i in indexed colour
+func (c *Context) SetDrawIndex(i int) {
+	if c.indexed == nil || len(c.indexed.pal) == 0 {
+		return
+	}
+	i = minInt(maxInt(i, 0), len(c.indexed.pal)-1)
+	c.index = uint8(i)
+	c.colour = c.indexed.pal[i]
+	c.Renderer.SetDrawColor(c.colour.Unpack())
+}
+
+// DrawIndex - palette entry being drawn with in indexed colour
+func (c *Context) DrawIndex() int {
+	return int(c.index)
+}
+
+// AddPaletteCycle - starts palette entries first to last cycling at rate (entries per Elapsed unit). Change the
+// fields of the returned cycle to adjust it
+func (c *Context) AddPaletteCycle(first, last int, rate float64) *PaletteCycle {
+	pc := &PaletteCycle{First: first, Last: last, Rate: rate}
+	if c.indexed != nil {
+		c.indexed.cycles = append(c.indexed.cycles, pc)
+	}
+	return pc
+}
+
+// ClearPaletteCycles - stops all palette cycling, putting the colours back where they were
+func (c *Context) ClearPaletteCycles() {
+	if c.indexed != nil {
+		c.indexed.cycles = nil
+	}
+}
+
+// FadePalette - shows every palette colour amount (0-1) of the way to col, e.g. to fade to black. 0 turns it off.
+// What is drawn is unchanged, so fading back is just a smaller amount
+func (c *Context) FadePalette(col Colour, amount float64) {
+	if c.indexed != nil {
+		c.indexed.fadeCol, c.indexed.fade = col, Clamp01(amount)
+	}
+}
+
+// nearest - palette index for col, remembered for next time as sprites use the same colours over and over
+func (f *indexedFrame) nearest(col Colour) uint8 {
+	return nearestCached(f.pal, f.near, col)
+}
+
+// nearestCached - palette index in pal for col, looked up in near first. Colours are remembered to the nearest
+// 8 bit value, and near is emptied when it gets too big
+func nearestCached(pal []Colour, near map[[3]uint8]uint8, col Colour) uint8 {
+	r, g, b, _ := col.Unpack()
+	key := [3]uint8{r, g, b}
+	i, ok := near[key]
+	if !ok {
+		if len(near) >= maxNear {
+			for k := range near {
+				delete(near, k)
+			}
+		}
+		i = uint8(NearestColour(pal, Colour{float64(r), float64(g), float64(b), 255}))
+		near[key] = i
+	}
+	return i
+}
+
+// clear - sets every block to index i
+func (f *indexedFrame) clear(i uint8) {
+	for p := range f.pix {
+		f.pix[p] = i
+	}
+}
+
+// fill - sets the blocks covered by the rectangle x, y, w, h to index i. Anything drawn covers at least one block
+func (f *indexedFrame) fill(x, y, w, h float64, i uint8) {
+	x0, y0 := int(math.Floor(x)), int(math.Floor(y))
+	x1, y1 := maxInt(int(math.Floor(x+w)), x0+1), maxInt(int(math.Floor(y+h)), y0+1)
+	x0, y0 = maxInt(x0, 0), maxInt(y0, 0)
+	x1, y1 = minInt(x1, f.w), minInt(y1, f.h)
+	for row := y0; row < y1; row++ {
+		for col := x0; col < x1; col++ {
+			f.pix[row*f.w+col] = i
+		}
+	}
+}
+
+// shown - the palette as it is shown this frame, with cycles moved on by elapsed and the fade applied
+func (f *indexedFrame) shown(elapsed float64) []Colour {
+	pal := append([]Colour(nil), f.pal...)
+	for _, pc := range f.cycles {
+		first, last := maxInt(pc.First, 0), minInt(pc.Last, len(pal)-1)
+		n := last - first + 1
+		if n < 2 {
+			continue
+		}
+		pc.pos = math.Mod(pc.pos+pc.Rate*elapsed, float64(n))
+		shift := int(math.Floor(pc.pos))
+		for i := 0; i < n; i++ {
+			pal[first+((i+shift)%n+n)%n] = f.pal[first+i]
+		}
+	}
+	if f.fade > 0 {
+		for i := range pal {
+			pal[i] = pal[i].Lerp(f.fadeCol, f.fade)
+		}
+	}
+	return pal
+}
+
+// render - turns the indices into colours in t
+func (f *indexedFrame) render(t *RenderTarget, elapsed float64) {
+	pal := f.shown(elapsed)
+	rgba := make([][4]uint8, len(pal))
+	for i, col := range pal {
+		r, g, b, _ := col.Unpack()
+		rgba[i] = [4]uint8{r, g, b, 255}
+	}
+	p := t.img.Pix
+	for i, ix := range f.pix {
+		if int(ix) < len(rgba) {
+			copy(p[i*4:i*4+4], rgba[ix][:])
+		} else {
+			copy(p[i*4:i*4+4], []uint8{0, 0, 0, 255})
+		}
+	}
+	t.mask = nil
+}
@@ -0,0 +1,29 @@
+package GameEngine
+
+import "testing"
+
+func TestIndexedNearestBounded(t *testing.T) {
+	c := &Context{Blocks: 4, ScrnWidth: 16, ScrnHeight: 16}
+	pal := []Colour{{0, 0, 0, 255}, {255, 255, 255, 255}}
+	c.SetIndexed(pal)
+	f := c.indexed
+	// colours a fraction apart round to the same 8 bits and share an entry
+	if f.nearest(Colour{200.1, 200.2, 200.3, 255}) != 1 || f.nearest(Colour{199.9, 200, 200.4, 128}) != 1 {
+		t.Fatal("wrong index")
+	}
+	if len(f.near) != 1 {
+		t.Fatalf("got %d entries, want 1", len(f.near))
+	}
+	// a gradient's worth of different colours doesn't grow it without end
+	for i := 0; i < 3*maxNear; i++ {
+		v := float64(i % 256)
+		col := Colour{v, float64(i / 256 % 256), float64(i / 65536), 255}
+		want := uint8(NearestColour(pal, col))
+		if got := f.nearest(col); got != want {
+			t.Fatalf("%v: got %d want %d", col, got, want)
+		}
+		if len(f.near) > maxNear {
+			t.Fatalf("%d entries", len(f.near))
+		}
+	}
+}
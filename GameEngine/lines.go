@@ -0,0 +1,298 @@
+package GameEngine
+
+import "math"
+
+// LineCap - how the ends of thick lines are drawn
+type LineCap int
+
+const (
+	// CapButt - the line stops square at its end points
+	CapButt LineCap = iota
+	// CapSquare - square, but carried on half the width past the end points
+	CapSquare
+	// CapRound - a half circle round each end point
+	CapRound
+)
+
+// LineJoin - how the corners of thick polylines are drawn
+type LineJoin int
+
+const (
+	// JoinMiter - the edges carried on until they meet in a point. Very sharp corners are bevelled instead
+	JoinMiter LineJoin = iota
+	// JoinBevel - the corner cut off flat
+	JoinBevel
+	// JoinRound - the corner rounded off
+	JoinRound
+)
+
+// miterLimit - how long (in line widths) a miter can get before it is bevelled instead, the same as SVG's default
+const miterLimit = 4.0
+
+// LineStyle - how Polyline and StyledLine draw. Width is in blocks; 1 or less is a thin line, anti-aliased if AA is
+// set. Dash is lengths (blocks) of line and gap taken in turn, e.g. {4, 2} for dashes or {0, 2} for a dot every
+// 2 blocks; nil is solid. Thin lines are always at least a block long, so their dashes are a block longer
+type LineStyle struct {
+	Width float64
+	Cap   LineCap
+	Join  LineJoin
+	Dash  []float64
+	AA    bool
+}
+
+// pointAlpha - block x, y in col with its alpha times cov (0-1). Indexed colour has no alpha, so there blocks over
+// half covered are drawn and the rest left
+func (c *Context) pointAlpha(col Colour, x, y, cov float64) {
+	if cov <= 0 {
+		return
+	}
+	if c.target == nil && c.indexed != nil {
+		cov = math.Round(Clamp01(cov))
+		if cov == 0 {
+			return
+		}
+	}
+	col.A *= Clamp01(cov)
+	c.SetDrawColor(col)
+	c.Point(x, y)
+}
+
+// fpart - the part of v after the point
+func fpart(v float64) float64 {
+	return v - math.Floor(v)
+}
+
+// AALine - anti-aliased line (blocks), Xiaolin Wu's way: the two blocks either side of the line share it, each
+// given alpha by how much of it they cover. Draw with SetBlendMode(BlendAlpha) to see it smoothed on the screen
+func (c *Context) AALine(x0, y0, x1, y1 float64) {
+	col := c.colour
+	defer c.SetDrawColor(col)
+	steep := math.Abs(y1-y0) > math.Abs(x1-x0)
+	if steep {
+		x0, y0, x1, y1 = y0, x0, y1, x1
+	}
+	if x0 > x1 {
+		x0, x1, y0, y1 = x1, x0, y1, y0
+	}
+	plot := func(x, y, cov float64) {
+		if steep {
+			x, y = y, x
+		}
+		c.pointAlpha(col, x, y, cov)
+	}
+	grad := 1.0
+	if dx := x1 - x0; dx != 0 {
+		grad = (y1 - y0) / dx
+	}
+
+	// the ends only cover part of their blocks
+	xs := math.Floor(x0 + 0.5)
+	ys := y0 + grad*(xs-x0)
+	gap := 1 - fpart(x0+0.5)
+	plot(xs, math.Floor(ys), (1-fpart(ys))*gap)
+	plot(xs, math.Floor(ys)+1, fpart(ys)*gap)
+	xe := math.Floor(x1 + 0.5)
+	ye := y1 + grad*(xe-x1)
+	gap = fpart(x1 + 0.5)
+	if xe == xs {
+		// a single block, already done
+		return
+	}
+	plot(xe, math.Floor(ye), (1-fpart(ye))*gap)
+	plot(xe, math.Floor(ye)+1, fpart(ye)*gap)
+
+	y := ys + grad
+	for x := xs + 1; x < xe; x++ {
+		plot(x, math.Floor(y), 1-fpart(y))
+		plot(x, math.Floor(y)+1, fpart(y))
+		y += grad
+	}
+}
+
+// AACircle - anti-aliased outline of the circle centred on x, y with radius r (blocks). See AALine
+func (c *Context) AACircle(x, y, r float64) {
+	if r < 0 {
+		return
+	}
+	col := c.colour
+	defer c.SetDrawColor(col)
+	x, y = math.Round(x), math.Round(y)
+	// an eighth of the circle, mirrored round. Blocks where the eighths meet keep their best coverage
+	cover := map[[2]float64]float64{}
+	add := func(dx, dy, cov float64) {
+		for _, p := range [][2]float64{{dx, dy}, {dy, dx}} {
+			for _, sx := range []float64{-1, 1} {
+				for _, sy := range []float64{-1, 1} {
+					k := [2]float64{x + sx*p[0], y + sy*p[1]}
+					cover[k] = math.Max(cover[k], cov)
+				}
+			}
+		}
+	}
+	for dx := 0.0; dx <= r/math.Sqrt2+1; dx++ {
+		dy := math.Sqrt(r*r - dx*dx)
+		if math.IsNaN(dy) || dy < dx-1 {
+			break
+		}
+		f := fpart(dy)
+		add(dx, math.Floor(dy), 1-f)
+		add(dx, math.Floor(dy)+1, f)
+	}
+	for p, cov := range cover {
+		c.pointAlpha(col, p[0], p[1], cov)
+	}
+}
+
+// StyledLine - line from x0, y0 to x1, y1 (blocks) drawn in style s
+func (c *Context) StyledLine(x0, y0, x1, y1 float64, s LineStyle) {
+	c.Polyline([]P2D{{X: x0, Y: y0}, {X: x1, Y: y1}}, s)
+}
+
+// Polyline - lines joining pts (blocks) one after another, drawn in style s. Dashes carry on round corners
+func (c *Context) Polyline(pts []P2D, s LineStyle) {
+	if len(pts) == 0 {
+		return
+	}
+	for _, piece := range dashes(pts, s.Dash) {
+		switch {
+		case s.Width > 1:
+			c.thickPolyline(piece, s)
+		case s.AA:
+			if len(piece) == 1 {
+				c.Point(piece[0].X, piece[0].Y)
+			}
+			for i := 1; i < len(piece); i++ {
+				c.AALine(piece[i-1].X, piece[i-1].Y, piece[i].X, piece[i].Y)
+			}
+		default:
+			c.lines(piece)
+		}
+	}
+}
+
+// dashes - pts cut up into the parts of the dash pattern that are drawn. No pattern is one solid piece
+func dashes(pts []P2D, pattern []float64) (out [][]P2D) {
+	total := 0.0
+	for _, d := range pattern {
+		if d < 0 {
+			return [][]P2D{pts}
+		}
+		total += d
+	}
+	if total <= 0 {
+		return [][]P2D{pts}
+	}
+	if len(pattern)%2 == 1 {
+		// an odd pattern swaps line and gap each time through, like SVG
+		pattern = append(append([]float64{}, pattern...), pattern...)
+	}
+	i, left := 0, pattern[0]
+	cur := []P2D{pts[0]}
+	for n := 1; n < len(pts); n++ {
+		a, b := pts[n-1], pts[n]
+		l := math.Hypot(b.X-a.X, b.Y-a.Y)
+		pos := 0.0
+		for l-pos > left {
+			pos += left
+			p := P2D{X: a.X + (b.X-a.X)*pos/l, Y: a.Y + (b.Y-a.Y)*pos/l}
+			if i%2 == 0 {
+				out = append(out, append(cur, p))
+				cur = nil
+			} else {
+				cur = []P2D{p}
+			}
+			i = (i + 1) % len(pattern)
+			left = pattern[i]
+		}
+		left -= l - pos
+		if i%2 == 0 {
+			cur = append(cur, b)
+		}
+	}
+	if i%2 == 0 && len(cur) > 0 {
+		out = append(out, cur)
+	}
+	return
+}
+
+// dot - filled circle of radius r centred on p, matching the edges of scanPolygon
+func (c *Context) dot(p P2D, r float64) {
+	c.scanPolygon(arcPoints(p.X, p.Y, r, r, 0, 2*math.Pi))
+}
+
+// thickPolyline - pts as lines s.Width across, with s's caps and joins
+func (c *Context) thickPolyline(pts []P2D, s LineStyle) {
+	hw := s.Width / 2
+	// leave out repeated points, which have no direction
+	path := pts[:1:1]
+	for _, p := range pts[1:] {
+		if last := path[len(path)-1]; p.X != last.X || p.Y != last.Y {
+			path = append(path, p)
+		}
+	}
+	if len(path) == 1 {
+		switch s.Cap {
+		case CapRound:
+			c.dot(path[0], hw)
+		case CapSquare:
+			c.scanPolygon([]P2D{{X: path[0].X - hw, Y: path[0].Y - hw}, {X: path[0].X + hw, Y: path[0].Y - hw},
+				{X: path[0].X + hw, Y: path[0].Y + hw}, {X: path[0].X - hw, Y: path[0].Y + hw}})
+		}
+		return
+	}
+	// unit direction and side (left of the direction, hw long) of each segment
+	dir := make([]V2D, len(path)-1)
+	side := make([]V2D, len(path)-1)
+	for i := range dir {
+		d := V2D{Dx: path[i+1].X - path[i].X, Dy: path[i+1].Y - path[i].Y}
+		l := d.Len()
+		dir[i] = V2D{Dx: d.Dx / l, Dy: d.Dy / l}
+		side[i] = V2D{Dx: -dir[i].Dy * hw, Dy: dir[i].Dx * hw}
+	}
+	if s.Cap == CapSquare {
+		path = append([]P2D{}, path...)
+		last := len(path) - 1
+		path[0] = P2D{X: path[0].X - dir[0].Dx*hw, Y: path[0].Y - dir[0].Dy*hw}
+		path[last] = P2D{X: path[last].X + dir[last-1].Dx*hw, Y: path[last].Y + dir[last-1].Dy*hw}
+	}
+	off := func(p P2D, v V2D, k float64) P2D {
+		return P2D{X: p.X + v.Dx*k, Y: p.Y + v.Dy*k}
+	}
+	for i := range dir {
+		a, b := path[i], path[i+1]
+		c.scanPolygon([]P2D{off(a, side[i], 1), off(b, side[i], 1), off(b, side[i], -1), off(a, side[i], -1)})
+	}
+	for i := 1; i < len(path)-1; i++ {
+		p, in, out := path[i], side[i-1], side[i]
+		// the outside of the corner is the side the path turns away from
+		turn := dir[i-1].Dx*dir[i].Dy - dir[i-1].Dy*dir[i].Dx
+		if turn == 0 {
+			continue
+		}
+		k := 1.0
+		if turn > 0 {
+			k = -1
+		}
+		a, b := off(p, in, k), off(p, out, k)
+		switch s.Join {
+		case JoinRound:
+			c.dot(p, hw)
+		case JoinMiter:
+			// the point is along the average of the two sides, far enough out to meet both edges
+			m := V2D{Dx: in.Dx + out.Dx, Dy: in.Dy + out.Dy}
+			cos := (m.Dx*in.Dx + m.Dy*in.Dy) / (m.Len() * hw)
+			if cos > 1/miterLimit {
+				tip := off(p, m, k*hw/(m.Len()*cos))
+				c.scanPolygon([]P2D{p, a, tip, b})
+				continue
+			}
+			fallthrough
+		default:
+			c.scanPolygon([]P2D{p, a, b})
+		}
+	}
+	if s.Cap == CapRound {
+		c.dot(path[0], hw)
+		c.dot(path[len(path)-1], hw)
+	}
+}
@@ -0,0 +1,175 @@
+package GameEngine
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Mask - collision bitmask, one bit per pixel, set where a sprite is solid. Rows are packed 64 pixels to a word
+// so two masks can be tested against each other 64 pixels at a time
+type Mask struct {
+	W, H  int
+	words int // words per row
+	bits  []uint64
+}
+
+// NewMask - empty mask of w x h pixels
+func NewMask(w, h int) *Mask {
+	words := (w + 63) / 64
+	return &Mask{W: w, H: h, words: words, bits: make([]uint64, words*h)}
+}
+
+// CollisionMask - mask of the whole sprite with a bit set for every pixel that isn't fully transparent. Built the
+// first time it is asked for and kept
+func (s *Sprite) CollisionMask() *Mask {
+	if s.mask == nil {
+		s.mask = s.CollisionMaskRect(0, 0, s.W, s.H)
+	}
+	return s.mask
+}
+
+// CollisionMaskRect - mask of the w x h part of the sprite at offset ox, oy. Not cached
+func (s *Sprite) CollisionMaskRect(ox, oy, w, h float64) *Mask {
+	m := NewMask(int(w), int(h))
+	bounds := s.Bounds()
+	for y := 0; y < m.H; y++ {
+		for x := 0; x < m.W; x++ {
+			_, _, _, a := s.At(bounds.Min.X+int(ox)+x, bounds.Min.Y+int(oy)+y).RGBA()
+			if a > 0 {
+				m.Set(x, y, true)
+			}
+		}
+	}
+	return m
+}
+
+// Collides - true if sprite s drawn at x, y touches sprite o drawn at ox, oy, pixel for pixel
+func (s *Sprite) Collides(x, y float64, o *Sprite, ox, oy float64) bool {
+	return s.CollisionMask().Overlaps(x, y, o.CollisionMask(), ox, oy)
+}
+
+// CollisionMask - mask of the sprite at row, col of the sheet
+func (s *SpriteSheet) CollisionMask(row, col float64) *Mask {
+	col = math.Mod(col, s.SpritesPerCol)
+	row = math.Mod(row, s.SpritesPerRow)
+	return s.Sheet.CollisionMaskRect(col*s.SpriteW, row*s.SpriteH, s.SpriteW, s.SpriteH)
+}
+
+// At - true if pixel x, y is solid. Outside the mask is never solid
+func (m *Mask) At(x, y int) bool {
+	if x < 0 || y < 0 || x >= m.W || y >= m.H {
+		return false
+	}
+	return m.bits[y*m.words+x/64]&(1<<uint(x%64)) != 0
+}
+
+// Set - sets or clears pixel x, y
+func (m *Mask) Set(x, y int, solid bool) {
+	if x < 0 || y < 0 || x >= m.W || y >= m.H {
+		return
+	}
+	if solid {
+		m.bits[y*m.words+x/64] |= 1 << uint(x%64)
+	} else {
+		m.bits[y*m.words+x/64] &^= 1 << uint(x%64)
+	}
+}
+
+// Count - number of solid pixels
+func (m *Mask) Count() (n int) {
+	for _, w := range m.bits {
+		n += bits.OnesCount64(w)
+	}
+	return
+}
+
+// FlipX - new mask mirrored left to right, for sprites drawn flipped
+func (m *Mask) FlipX() *Mask {
+	f := NewMask(m.W, m.H)
+	for y := 0; y < m.H; y++ {
+		for x := 0; x < m.W; x++ {
+			if m.At(x, y) {
+				f.Set(m.W-1-x, y, true)
+			}
+		}
+	}
+	return f
+}
+
+// FlipY - new mask mirrored top to bottom
+func (m *Mask) FlipY() *Mask {
+	f := NewMask(m.W, m.H)
+	for y := 0; y < m.H; y++ {
+		copy(f.bits[(m.H-1-y)*m.words:(m.H-y)*m.words], m.bits[y*m.words:(y+1)*m.words])
+	}
+	return f
+}
+
+// Overlaps - true if mask m placed with its top left at x, y has a solid pixel on top of a solid pixel of
+// mask o placed at ox, oy. Positions are rounded to whole pixels
+func (m *Mask) Overlaps(x, y float64, o *Mask, ox, oy float64) bool {
+	found := false
+	m.overlap(x, y, o, ox, oy, func(px, py int, hits uint64) bool {
+		found = true
+		return true
+	})
+	return found
+}
+
+// OverlapPixels - positions of every pixel where masks m at x, y and o at ox, oy are both solid
+func (m *Mask) OverlapPixels(x, y float64, o *Mask, ox, oy float64) (ps []P2D) {
+	m.overlap(x, y, o, ox, oy, func(px, py int, hits uint64) bool {
+		for hits != 0 {
+			b := bits.TrailingZeros64(hits)
+			ps = append(ps, P2D{X: float64(px + b), Y: float64(py)})
+			hits &= hits - 1
+		}
+		return false
+	})
+	return
+}
+
+// overlap - ANDs the masks together 64 pixels at a time over the area they share. fn gets the screen position
+// of the first pixel of each run and the bits where both are solid. Stops when fn returns true
+func (m *Mask) overlap(x, y float64, o *Mask, ox, oy float64, fn func(px, py int, hits uint64) bool) {
+	ax, ay := int(math.Round(x)), int(math.Round(y))
+	bx, by := int(math.Round(ox)), int(math.Round(oy))
+	x0, x1 := maxInt(ax, bx), minInt(ax+m.W, bx+o.W)
+	y0, y1 := maxInt(ay, by), minInt(ay+m.H, by+o.H)
+	for py := y0; py < y1; py++ {
+		for px := x0; px < x1; px += 64 {
+			hits := m.run(py-ay, px-ax) & o.run(py-by, px-bx)
+			if n := x1 - px; n < 64 {
+				hits &= 1<<uint(n) - 1
+			}
+			if hits != 0 && fn(px, py, hits) {
+				return
+			}
+		}
+	}
+}
+
+// run - 64 bits of row y starting at pixel x
+func (m *Mask) run(y, x int) uint64 {
+	row := m.bits[y*m.words : (y+1)*m.words]
+	w, s := x/64, uint(x%64)
+	r := row[w] >> s
+	if s > 0 && w+1 < len(row) {
+		r |= row[w+1] << (64 - s)
+	}
+	return r
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,312 @@
+package GameEngine
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FaceVertex - indexes (0 based) into the positions, texture coords and normals of a Mesh. -1 if not given
+type FaceVertex struct {
+	V  int
+	VT int
+	VN int
+}
+
+// Face - a single triangle of a mesh and the name of the material it uses
+type Face struct {
+	Verts    [3]FaceVertex
+	Material string
+}
+
+// Material - colours (0-255) and texture names loaded from a .mtl file
+type Material struct {
+	Name    string
+	Ka      Colour // ambient
+	Kd      Colour // diffuse
+	Ks      Colour // specular
+	Ns      float64
+	D       float64 // dissolve (1 is opaque)
+	MapKd   string  // diffuse texture filename
+	Texture *Sprite // loaded from MapKd by LoadOBJ if it is set
+}
+
+// Mesh - triangle mesh loaded from a Wavefront .obj file
+type Mesh struct {
+	Name      string
+	Positions []P3D
+	Normals   []V3D
+	TexCoords []P2D
+	Faces     []Face
+	MtlLibs   []string
+	Materials map[string]*Material
+}
+
+// ParseError - reports a malformed line in a file being loaded, e.g. an .obj or .mtl file
+type ParseError struct {
+	File string
+	Line int
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Msg)
+}
+
+// LoadOBJ - loads a mesh from an .obj file along with any .mtl libraries and textures it refers to
+func LoadOBJ(filename string) (m *Mesh, err error) {
+	infile, err := os.Open(filename)
+	if err != nil {
+		return
+	}
+	defer infile.Close()
+
+	m, err = ParseOBJ(infile, filepath.Base(filename))
+	if err != nil {
+		return nil, err
+	}
+	// material and texture names are relative to the .obj file
+	dir := filepath.Dir(filename)
+	for _, lib := range m.MtlLibs {
+		mats, err := LoadMTL(filepath.Join(dir, lib))
+		if err != nil {
+			return nil, err
+		}
+		for name, mat := range mats {
+			m.Materials[name] = mat
+		}
+	}
+	for _, mat := range m.Materials {
+		if mat.MapKd == "" {
+			continue
+		}
+		mat.Texture, err = NewSprite(filepath.Join(dir, mat.MapKd))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// ParseOBJ - reads a mesh from r. name is only used in error messages. Polygons are triangulated as fans
+// so should be convex. mtllib names are recorded in MtlLibs but not loaded
+func ParseOBJ(r io.Reader, name string) (m *Mesh, err error) {
+	m = &Mesh{Materials: map[string]*Material{}}
+	material := ""
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(stripComment(scanner.Text()))
+		if len(fields) == 0 {
+			continue
+		}
+		fail := func(format string, a ...interface{}) error {
+			return &ParseError{File: name, Line: line, Msg: fmt.Sprintf(format, a...)}
+		}
+		args := fields[1:]
+		switch fields[0] {
+		case "v":
+			f, err := parseFloats(args, 3, 4)
+			if err != nil {
+				return nil, fail("bad vertex: %v", err)
+			}
+			m.Positions = append(m.Positions, P3D{X: f[0], Y: f[1], Z: f[2]})
+		case "vn":
+			f, err := parseFloats(args, 3, 3)
+			if err != nil {
+				return nil, fail("bad normal: %v", err)
+			}
+			m.Normals = append(m.Normals, V3D{DX: f[0], DY: f[1], DZ: f[2]})
+		case "vt":
+			f, err := parseFloats(args, 1, 3)
+			if err != nil {
+				return nil, fail("bad texture coord: %v", err)
+			}
+			if len(f) == 1 {
+				f = append(f, 0)
+			}
+			m.TexCoords = append(m.TexCoords, P2D{X: f[0], Y: f[1]})
+		case "f":
+			if len(args) < 3 {
+				return nil, fail("face needs at least 3 vertices, got %d", len(args))
+			}
+			verts := make([]FaceVertex, len(args))
+			for i, a := range args {
+				verts[i], err = m.parseFaceVertex(a)
+				if err != nil {
+					return nil, fail("bad face vertex %q: %v", a, err)
+				}
+			}
+			for i := 1; i < len(verts)-1; i++ {
+				m.Faces = append(m.Faces, Face{Verts: [3]FaceVertex{verts[0], verts[i], verts[i+1]}, Material: material})
+			}
+		case "usemtl":
+			if len(args) != 1 {
+				return nil, fail("usemtl needs a material name")
+			}
+			material = args[0]
+		case "mtllib":
+			if len(args) == 0 {
+				return nil, fail("mtllib needs a filename")
+			}
+			m.MtlLibs = append(m.MtlLibs, args...)
+		case "o":
+			if len(args) > 0 {
+				m.Name = args[0]
+			}
+		default:
+			// groups, smoothing, lines and the rest are not needed to draw
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// parseFaceVertex - parses v, v/vt, v//vn or v/vt/vn. Negative indexes count back from the last defined
+func (m *Mesh) parseFaceVertex(s string) (fv FaceVertex, err error) {
+	parts := strings.Split(s, "/")
+	if len(parts) > 3 {
+		return fv, fmt.Errorf("too many parts")
+	}
+	idx := [3]int{-1, -1, -1}
+	counts := [3]int{len(m.Positions), len(m.TexCoords), len(m.Normals)}
+	for i, p := range parts {
+		if p == "" {
+			if i == 0 {
+				return fv, fmt.Errorf("missing vertex index")
+			}
+			continue
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return fv, err
+		}
+		switch {
+		case n > 0:
+			n--
+		case n < 0:
+			n += counts[i]
+		default:
+			return fv, fmt.Errorf("index 0 is not allowed")
+		}
+		if n < 0 || n >= counts[i] {
+			return fv, fmt.Errorf("index %s out of range", p)
+		}
+		idx[i] = n
+	}
+	return FaceVertex{V: idx[0], VT: idx[1], VN: idx[2]}, nil
+}
+
+// LoadMTL - loads the materials in an .mtl file keyed by name
+func LoadMTL(filename string) (mats map[string]*Material, err error) {
+	infile, err := os.Open(filename)
+	if err != nil {
+		return
+	}
+	defer infile.Close()
+	return ParseMTL(infile, filepath.Base(filename))
+}
+
+// ParseMTL - reads materials from r. name is only used in error messages
+func ParseMTL(r io.Reader, name string) (mats map[string]*Material, err error) {
+	mats = map[string]*Material{}
+	var mat *Material
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(stripComment(scanner.Text()))
+		if len(fields) == 0 {
+			continue
+		}
+		fail := func(format string, a ...interface{}) error {
+			return &ParseError{File: name, Line: line, Msg: fmt.Sprintf(format, a...)}
+		}
+		args := fields[1:]
+		if fields[0] == "newmtl" {
+			if len(args) != 1 {
+				return nil, fail("newmtl needs a material name")
+			}
+			mat = &Material{Name: args[0], Kd: NewColour(255, 255, 255, 255), D: 1}
+			mats[mat.Name] = mat
+			continue
+		}
+		if mat == nil {
+			return nil, fail("%s before newmtl", fields[0])
+		}
+		switch fields[0] {
+		case "Ka", "Kd", "Ks":
+			f, err := parseFloats(args, 3, 3)
+			if err != nil {
+				return nil, fail("bad %s colour: %v", fields[0], err)
+			}
+			col := NewColour(f[0]*255, f[1]*255, f[2]*255, 255)
+			switch fields[0] {
+			case "Ka":
+				mat.Ka = col
+			case "Kd":
+				mat.Kd = col
+			case "Ks":
+				mat.Ks = col
+			}
+		case "Ns", "d", "Tr":
+			f, err := parseFloats(args, 1, 1)
+			if err != nil {
+				return nil, fail("bad %s: %v", fields[0], err)
+			}
+			switch fields[0] {
+			case "Ns":
+				mat.Ns = f[0]
+			case "d":
+				mat.D = f[0]
+			case "Tr":
+				mat.D = 1 - f[0]
+			}
+		case "map_Kd":
+			if len(args) == 0 {
+				return nil, fail("map_Kd needs a filename")
+			}
+			// options come before the filename so take the last field
+			mat.MapKd = args[len(args)-1]
+		default:
+			// illum, other maps etc. are not used
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// stripComment - removes anything after a #
+func stripComment(s string) string {
+	if i := strings.IndexByte(s, '#'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// parseFloats - parses between min and max floats from args
+func parseFloats(args []string, min, max int) (f []float64, err error) {
+	if len(args) < min || len(args) > max {
+		if min == max {
+			return nil, fmt.Errorf("want %d numbers, got %d", min, len(args))
+		}
+		return nil, fmt.Errorf("want %d to %d numbers, got %d", min, max, len(args))
+	}
+	f = make([]float64, len(args))
+	for i, a := range args {
+		f[i], err = strconv.ParseFloat(a, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return
+}
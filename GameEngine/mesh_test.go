@@ -0,0 +1,132 @@
+package GameEngine
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoadOBJCube(t *testing.T) {
+	m, err := LoadOBJ("../assets/cube.obj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Name != "cube" || len(m.Positions) != 8 || len(m.Normals) != 6 || len(m.TexCoords) != 4 {
+		t.Errorf("got %q with %d positions, %d normals, %d texture coords", m.Name, len(m.Positions), len(m.Normals), len(m.TexCoords))
+	}
+	// six quads, two triangles each
+	if len(m.Faces) != 12 {
+		t.Errorf("got %d triangles, want 12", len(m.Faces))
+	}
+	if len(m.Materials) != 2 || m.Materials["red"] == nil || m.Materials["steelblue"] == nil {
+		t.Fatalf("got materials %v", m.Materials)
+	}
+	uses := map[string]int{}
+	for _, f := range m.Faces {
+		uses[f.Material]++
+	}
+	if uses["red"] != 4 || uses["steelblue"] != 8 {
+		t.Errorf("got material use %v", uses)
+	}
+	if red := m.Materials["red"]; red.Kd != NewColour(0.8*255, 0.1*255, 0.1*255, 255) || red.D != 1 {
+		t.Errorf("got red %+v", red)
+	}
+	// the first quad, f 1/1/1 4/4/1 3/3/1 2/2/1, as a fan from its first corner
+	want := [2][3]FaceVertex{
+		{{0, 0, 0}, {3, 3, 0}, {2, 2, 0}},
+		{{0, 0, 0}, {2, 2, 0}, {1, 1, 0}},
+	}
+	for i, w := range want {
+		if m.Faces[i].Verts != w {
+			t.Errorf("triangle %d: got %v want %v", i, m.Faces[i].Verts, w)
+		}
+	}
+}
+
+func TestParseOBJFan(t *testing.T) {
+	m, err := ParseOBJ(strings.NewReader(`
+v 0 0 0
+v 1 0 0
+v 1 1 0
+v 0.5 2 0
+v 0 1 0
+f 1 2 3 4 5
+`), "fan.obj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Faces) != 3 {
+		t.Fatalf("got %d triangles, want 3", len(m.Faces))
+	}
+	for i, f := range m.Faces {
+		if f.Verts[0].V != 0 || f.Verts[1].V != i+1 || f.Verts[2].V != i+2 {
+			t.Errorf("triangle %d: got %v", i, f.Verts)
+		}
+		if f.Verts[0].VT != -1 || f.Verts[0].VN != -1 {
+			t.Errorf("triangle %d: got texture coord or normal %v", i, f.Verts[0])
+		}
+	}
+}
+
+func TestParseOBJNegativeIndexes(t *testing.T) {
+	m, err := ParseOBJ(strings.NewReader(`
+v 0 0 0
+v 1 0 0
+vt 0 0
+vn 0 0 1
+v 1 1 0
+vt 1 1
+f -3/-2/-1 -2//-1 -1/-1
+`), "neg.obj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// counted back from the last defined when the face is read
+	want := [3]FaceVertex{{0, 0, 0}, {1, -1, 0}, {2, 1, -1}}
+	if len(m.Faces) != 1 || m.Faces[0].Verts != want {
+		t.Fatalf("got %v want %v", m.Faces, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		mtl   bool
+		input string
+		line  int
+	}{
+		{"short vertex", false, "v 1 2 3\nv 1 2\n", 2},
+		{"bad number", false, "# comment\n\nv 1 x 3\n", 3},
+		{"short normal", false, "vn 1 2\n", 1},
+		{"short face", false, "v 0 0 0\nv 1 0 0\nf 1 2\n", 3},
+		{"index out of range", false, "v 0 0 0\nv 1 0 0\nv 1 1 0\nf 1 2 4\n", 4},
+		{"index 0", false, "v 0 0 0\nv 1 0 0\nv 1 1 0\nf 0 1 2\n", 4},
+		{"negative out of range", false, "v 0 0 0\nv 1 0 0\nv 1 1 0\nf 1 2 -4\n", 4},
+		{"too many parts", false, "v 0 0 0\nv 1 0 0\nv 1 1 0\nf 1/1/1/1 2 3\n", 4},
+		{"usemtl no name", false, "usemtl\n", 1},
+		{"mtllib no name", false, "mtllib\n", 1},
+		{"before newmtl", true, "Kd 1 1 1\n", 1},
+		{"newmtl no name", true, "newmtl\n", 1},
+		{"bad colour", true, "newmtl a\nKd 1 1\n", 2},
+		{"bad dissolve", true, "newmtl a\nKd 1 1 1\nd half\n", 3},
+		{"map_Kd no file", true, "newmtl a\nmap_Kd\n", 2},
+	}
+	for _, tt := range tests {
+		file := "bad.obj"
+		var err error
+		if tt.mtl {
+			file = "bad.mtl"
+			_, err = ParseMTL(strings.NewReader(tt.input), file)
+		} else {
+			_, err = ParseOBJ(strings.NewReader(tt.input), file)
+		}
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("%s: got %v, want a *ParseError", tt.name, err)
+			continue
+		}
+		if pe.File != file || pe.Line != tt.line {
+			t.Errorf("%s: got %s:%d want %s:%d (%v)", tt.name, pe.File, pe.Line, file, tt.line, pe)
+		}
+	}
+}
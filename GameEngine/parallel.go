@@ -0,0 +1,141 @@
+package GameEngine
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ParallelFor - splits 0 to n-1 into runs, one for each CPU, and calls fn(from, to) (to not included) for each
+// run on its own goroutine. Returns when they have all finished. fn must only change things that belong to its run
+func ParallelFor(n int, fn func(from, to int)) {
+	workers := minInt(runtime.GOMAXPROCS(0), n)
+	if workers <= 1 {
+		if n > 0 {
+			fn(0, n)
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func(from, to int) {
+			defer wg.Done()
+			fn(from, to)
+		}(i*n/workers, (i+1)*n/workers)
+	}
+	wg.Wait()
+}
+
+// Surface - the blocks being drawn to, for drawing from several goroutines at once (see ParallelRows). x, y are
+// blocks of the screen or render target: the screen transform and camera aren't used. Each goroutine gets its
+// own, and must only touch its own rows or columns
+type Surface struct {
+	W, H int
+	c    *Context
+	t    *RenderTarget      // nil in indexed colour or when drawing straight to the screen
+	near map[[3]uint8]uint8 // palette indices found by this goroutine in indexed colour
+}
+
+// newSurface - a Surface for one goroutine
+func (c *Context) newSurface() *Surface {
+	s := &Surface{W: int(c.ScrnWidth), H: int(c.ScrnHeight), c: c}
+	switch {
+	case c.target != nil:
+		s.t = c.target
+	case c.indexed != nil:
+		s.near = map[[3]uint8]uint8{}
+	default:
+		s.t = c.frame
+	}
+	if s.t != nil {
+		s.W, s.H = int(s.t.W), int(s.t.H)
+	}
+	return s
+}
+
+// Set - draws block x, y in col, blended by the context's blend mode. Outside is ignored
+func (s *Surface) Set(x, y int, col Colour) {
+	if x < 0 || y < 0 || x >= s.W || y >= s.H {
+		return
+	}
+	c := s.c
+	switch {
+	case s.t != nil:
+		// straight into the pixels, as RenderTarget.Set would throw away the mask from every goroutine
+		i := (y*s.W + x) * 4
+		p := s.t.img.Pix
+		if c.blend != BlendNone {
+			col = blendColour(Colour{float64(p[i]), float64(p[i+1]), float64(p[i+2]), float64(p[i+3])}, col, c.blend)
+		}
+		p[i], p[i+1], p[i+2], p[i+3] = col.Unpack()
+	case c.indexed != nil:
+		f := c.indexed
+		f.pix[y*f.w+x] = nearestCached(f.pal, s.near, col)
+	default:
+		// only ever one goroutine when drawing to the screen
+		c.SetDrawColor(col)
+		c.fill(float64(x)*c.Blocks, float64(y)*c.Blocks, c.Blocks, c.Blocks)
+	}
+}
+
+// At - colour of block x, y as drawn so far. Colour{} outside, or when drawing straight to the screen
+func (s *Surface) At(x, y int) Colour {
+	if x < 0 || y < 0 || x >= s.W || y >= s.H {
+		return Colour{}
+	}
+	if s.t != nil {
+		return rgbaAt(s.t)(x, y)
+	}
+	if f := s.c.indexed; f != nil {
+		if i := int(f.pix[y*f.w+x]); i < len(f.pal) {
+			return f.pal[i]
+		}
+		return Colour{A: 255}
+	}
+	return Colour{}
+}
+
+// SurfaceSize - width and height in blocks of what ParallelRows and ParallelColumns draw to: the render target if
+// one is set, otherwise the screen
+func (c *Context) SurfaceSize() (w, h int) {
+	if c.target != nil {
+		return int(c.target.W), int(c.target.H)
+	}
+	return int(c.ScrnWidth), int(c.ScrnHeight)
+}
+
+// ParallelRows - calls fn for every row y of the screen (or render target), shared out over goroutines, and waits
+// for them. fn must only draw in its own row. Drawing straight to the screen (see SetFrameBuffer) it all runs on
+// this goroutine
+func (c *Context) ParallelRows(fn func(s *Surface, y int)) {
+	s := c.newSurface()
+	c.parallel(s.H, fn)
+}
+
+// ParallelColumns - like ParallelRows but for every column x
+func (c *Context) ParallelColumns(fn func(s *Surface, x int)) {
+	s := c.newSurface()
+	c.parallel(s.W, fn)
+}
+
+// parallel - runs fn for 0 to n-1 with a Surface for each goroutine
+func (c *Context) parallel(n int, fn func(s *Surface, i int)) {
+	col := c.colour
+	if _, _, _, ok := c.surface(); !ok {
+		s := c.newSurface()
+		for i := 0; i < n; i++ {
+			fn(s, i)
+		}
+		c.SetDrawColor(col)
+		return
+	}
+	ParallelFor(n, func(from, to int) {
+		s := c.newSurface()
+		for i := from; i < to; i++ {
+			fn(s, i)
+		}
+	})
+	if c.target != nil {
+		c.target.mask = nil
+	}
+}
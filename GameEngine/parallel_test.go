@@ -0,0 +1,89 @@
+package GameEngine
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+// Run with -race: each goroutine must only touch its own rows or columns
+
+func TestParallelFor(t *testing.T) {
+	for _, n := range []int{0, 1, 7, 1000} {
+		seen := make([]int32, n)
+		ParallelFor(n, func(from, to int) {
+			for i := from; i < to; i++ {
+				atomic.AddInt32(&seen[i], 1)
+			}
+		})
+		for i, s := range seen {
+			if s != 1 {
+				t.Fatalf("n=%d: %d visited %d times", n, i, s)
+			}
+		}
+	}
+}
+
+// pattern - a different colour for every block
+func pattern(x, y int) Colour {
+	return Colour{float64(x), float64(y), float64((x + y) % 256), 255}
+}
+
+func TestParallelRenderTarget(t *testing.T) {
+	c := &Context{Blocks: 4, ScrnWidth: 64, ScrnHeight: 48}
+	// a different size to the screen, the surface must follow the target
+	rt := NewRenderTarget(100, 30)
+	c.SetRenderTarget(rt)
+	if w, h := c.SurfaceSize(); w != 100 || h != 30 {
+		t.Fatalf("got size %d, %d", w, h)
+	}
+	c.ParallelRows(func(s *Surface, y int) {
+		if s.W != 100 || s.H != 30 {
+			t.Errorf("surface %d, %d", s.W, s.H)
+		}
+		for x := 0; x < s.W; x++ {
+			s.Set(x, y, pattern(x, y))
+		}
+	})
+	c.SetBlendMode(BlendAdd)
+	c.ParallelColumns(func(s *Surface, x int) {
+		for y := 0; y < s.H; y++ {
+			if s.At(x, y) != pattern(x, y) {
+				t.Errorf("%d, %d: got %v", x, y, s.At(x, y))
+			}
+			s.Set(x, y, Colour{0, 0, 1, 255})
+		}
+	})
+	for y := 0; y < 30; y++ {
+		for x := 0; x < 100; x++ {
+			want := pattern(x, y)
+			want.B = float64(minInt(int(want.B)+1, 255))
+			if got := rt.Pixel(x, y); got != want {
+				t.Fatalf("%d, %d: got %v want %v", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestParallelIndexed(t *testing.T) {
+	c := &Context{Blocks: 4, ScrnWidth: 64, ScrnHeight: 48}
+	pal := []Colour{{0, 0, 0, 255}, {255, 0, 0, 255}, {0, 255, 0, 255}, {0, 0, 255, 255}}
+	c.SetIndexed(pal)
+	c.ParallelRows(func(s *Surface, y int) {
+		for x := 0; x < s.W; x++ {
+			// near enough to pick out a palette colour
+			col := pal[(x+y)%len(pal)]
+			col.R = Clamp(col.R-10, 0, 255)
+			s.Set(x, y, col)
+		}
+	})
+	c.ParallelColumns(func(s *Surface, x int) {
+		for y := 0; y < s.H; y++ {
+			if want := pal[(x+y)%len(pal)]; s.At(x, y) != want {
+				t.Errorf("%d, %d: got %v want %v", x, y, s.At(x, y), want)
+			}
+		}
+	})
+	if got := c.GetPoint(5, 6); got != pal[3] {
+		t.Fatalf("got %v", got)
+	}
+}
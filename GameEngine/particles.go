@@ -0,0 +1,191 @@
+package GameEngine
+
+import (
+	"math"
+	"math/rand"
+)
+
+// ParticleShape - how particles are drawn
+type ParticleShape int
+
+const (
+	// ParticlePoint - a single block
+	ParticlePoint ParticleShape = iota
+	// ParticleCircle - filled circle with the particle's size as radius
+	ParticleCircle
+	// ParticleSprite - the emitter's Sprite centred on the particle
+	ParticleSprite
+)
+
+// CurveStop - value at time T (0-1) through a particle's life
+type CurveStop struct {
+	T float64
+	V float64
+}
+
+// Curve - values blended between stops. Stops must be in order of T
+type Curve []CurveStop
+
+// At - value of the curve at t. Before the first stop or after the last it is that stop's value
+func (cv Curve) At(t float64) float64 {
+	if len(cv) == 0 {
+		return 1
+	}
+	if t <= cv[0].T {
+		return cv[0].V
+	}
+	for i := 1; i < len(cv); i++ {
+		if t <= cv[i].T {
+			a, b := cv[i-1], cv[i]
+			return a.V + (b.V-a.V)*(t-a.T)/(b.T-a.T)
+		}
+	}
+	return cv[len(cv)-1].V
+}
+
+// Particle - one particle of an emitter
+type Particle struct {
+	Pos  P2D
+	Vel  V2D
+	Age  float64
+	Life float64
+	col  Gradient
+}
+
+// ParticleEmitter - spits out particles from a fixed size pool, either steadily (Rate) or in bursts (Burst).
+// Times and speeds are in Elapsed units. Angles are in radians with 0 along +x
+type ParticleEmitter struct {
+	Pos         P2D
+	Vel         V2D     // added to every new particle's velocity, e.g. the velocity of whatever is exploding
+	Radius      float64 // new particles start anywhere within this distance of Pos
+	Rate        float64 // particles made per unit of time. 0 for bursts only
+	Life        float64
+	LifeSpread  float64 // life is Life +/- up to this much
+	Speed       float64
+	SpeedSpread float64 // speed is Speed +/- up to this much
+	Angle       float64 // direction particles are fired in
+	Spread      float64 // particles fire within Angle +/- Spread/2. 2*PI for all round
+	Gravity     V2D
+	Drag        float64 // fraction of velocity lost per unit of time
+	// Colours - colour over each particle's life. Each particle picks one of these at random when it is made
+	Colours []Gradient
+	Sizes   Curve // size over each particle's life, for circles
+	Shape   ParticleShape
+	Sprite  *Sprite
+	// WrapW, WrapH - if not 0, particles wrap round 0 to WrapW and 0 to WrapH as they move, to stay with
+	// everything else on a screen that wraps
+	WrapW, WrapH float64
+
+	particles []Particle
+	live      int
+	due       float64 // part particles owed by Rate
+}
+
+// NewParticleEmitter - emitter at x, y that can have up to max particles alive at once. Starts with white
+// points fading to black over a life of 100, fired all round
+func NewParticleEmitter(x, y float64, max int) *ParticleEmitter {
+	return &ParticleEmitter{
+		Pos:       P2D{X: x, Y: y},
+		Life:      100,
+		Speed:     0.1,
+		Spread:    2 * PI,
+		Colours:   []Gradient{{{0, NewColour(255, 255, 255, 255)}, {1, NewColour(0, 0, 0, 255)}}},
+		Sizes:     Curve{{0, 1}},
+		particles: make([]Particle, max),
+	}
+}
+
+// Burst - makes n particles now. Any that don't fit in the pool are dropped
+func (e *ParticleEmitter) Burst(n int) {
+	for i := 0; i < n && e.live < len(e.particles); i++ {
+		e.spawn()
+	}
+}
+
+// Alive - number of particles alive
+func (e *ParticleEmitter) Alive() int {
+	return e.live
+}
+
+// Particles - the live particles. Only good until the next Update or Burst
+func (e *ParticleEmitter) Particles() []Particle {
+	return e.particles[:e.live]
+}
+
+// Done - true if the emitter has stopped making particles and all of them have died
+func (e *ParticleEmitter) Done() bool {
+	return e.Rate <= 0 && e.live == 0
+}
+
+// Clear - kills every particle
+func (e *ParticleEmitter) Clear() {
+	e.live = 0
+	e.due = 0
+}
+
+// Update - ages and moves the particles and makes new ones at Rate
+func (e *ParticleEmitter) Update(elapsed float64) {
+	drag := math.Exp(-e.Drag * elapsed)
+	for i := 0; i < e.live; {
+		p := &e.particles[i]
+		p.Age += elapsed
+		if p.Age >= p.Life {
+			// dead - swap in the last live one
+			e.live--
+			e.particles[i] = e.particles[e.live]
+			continue
+		}
+		p.Vel = p.Vel.Add(e.Gravity.Scale(elapsed)).Scale(drag)
+		p.Pos = p.Pos.Add(p.Vel.Scale(elapsed))
+		if e.WrapW > 0 {
+			p.Pos.X = Wrap(p.Pos.X, 0, e.WrapW)
+		}
+		if e.WrapH > 0 {
+			p.Pos.Y = Wrap(p.Pos.Y, 0, e.WrapH)
+		}
+		i++
+	}
+	if e.Rate > 0 {
+		e.due += e.Rate * elapsed
+		n := math.Floor(e.due)
+		e.due -= n
+		e.Burst(int(n))
+	}
+}
+
+// Draw - draws the live particles
+func (e *ParticleEmitter) Draw(c *Context) {
+	for i := 0; i < e.live; i++ {
+		p := &e.particles[i]
+		t := p.Age / p.Life
+		c.SetDrawColor(p.col.At(t))
+		switch e.Shape {
+		case ParticleCircle:
+			c.DrawFillCircle(p.Pos.X, p.Pos.Y, e.Sizes.At(t))
+		case ParticleSprite:
+			if e.Sprite != nil {
+				e.Sprite.DrawSprite(c, math.Round(p.Pos.X-e.Sprite.W/2), math.Round(p.Pos.Y-e.Sprite.H/2))
+			}
+		default:
+			c.Point(p.Pos.X, p.Pos.Y)
+		}
+	}
+}
+
+// spawn - starts a new particle in the next free slot
+func (e *ParticleEmitter) spawn() {
+	p := &e.particles[e.live]
+	e.live++
+	r := e.Radius * math.Sqrt(rand.Float64())
+	a := rand.Float64() * 2 * PI
+	p.Pos = P2D{X: e.Pos.X + math.Cos(a)*r, Y: e.Pos.Y + math.Sin(a)*r}
+	a = e.Angle + (rand.Float64()-0.5)*e.Spread
+	speed := e.Speed + (rand.Float64()*2-1)*e.SpeedSpread
+	p.Vel = e.Vel.Add(V2D{Dx: math.Cos(a) * speed, Dy: math.Sin(a) * speed})
+	p.Age = 0
+	p.Life = math.Max(e.Life+(rand.Float64()*2-1)*e.LifeSpread, 1e-9)
+	p.col = nil
+	if len(e.Colours) > 0 {
+		p.col = e.Colours[rand.Intn(len(e.Colours))]
+	}
+}
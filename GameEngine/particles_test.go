@@ -0,0 +1,34 @@
+package GameEngine
+
+import "testing"
+
+func TestParticlesWrap(t *testing.T) {
+	e := NewParticleEmitter(8, 5, 1)
+	e.Life = 1000
+	e.Spread = 0
+	e.Speed = 1
+	e.WrapW, e.WrapH = 10, 10
+	e.Burst(1)
+	// one block a step along +x: 9, then 10, then round to 1
+	for i, want := range []float64{9, 10, 1, 2} {
+		e.Update(1)
+		p := e.Particles()[0]
+		if p.Pos.X != want || p.Pos.Y != 5 {
+			t.Fatalf("step %d: got %v, want %v, 5", i, p.Pos, want)
+		}
+	}
+}
+
+func TestParticlesNoWrap(t *testing.T) {
+	e := NewParticleEmitter(8, 5, 1)
+	e.Life = 1000
+	e.Spread = 0
+	e.Speed = 1
+	e.Burst(1)
+	for i := 0; i < 5; i++ {
+		e.Update(1)
+	}
+	if p := e.Particles()[0]; p.Pos.X != 13 || p.Pos.Y != 5 {
+		t.Fatalf("got %v", p.Pos)
+	}
+}
@@ -0,0 +1,172 @@
+// Package physics - small 2D rigid body physics for GameEngine. Bodies are circles or convex polygons, moved with
+// a fixed time step and bounced off each other with impulses. Time is in the units Context.Elapsed gives
+package physics
+
+import (
+	"math"
+
+	"github.com/kevincolyer/GameEngine/GameEngine"
+	"github.com/kevincolyer/GameEngine/GameEngine/collision"
+)
+
+// BodyType - how a body takes part in the simulation
+type BodyType int
+
+const (
+	// Dynamic - moved by gravity, forces and collisions
+	Dynamic BodyType = iota
+	// Static - never moves. Floors and walls
+	Static
+	// Kinematic - moves at the velocity you give it, pushes dynamic bodies but is never pushed back
+	Kinematic
+)
+
+// Body - a rigid body. Pos is the centre of mass
+type Body struct {
+	Type        BodyType
+	Pos         GameEngine.P2D
+	Angle       float64
+	Vel         GameEngine.V2D
+	AngVel      float64
+	Restitution float64 // bounciness 0-1
+	Friction    float64
+	Sleeping    bool
+	Data        interface{} // anything the game wants to hang on the body
+
+	mass, invMass       float64
+	inertia, invInertia float64
+	radius              float64          // circle if > 0
+	points              []GameEngine.P2D // polygon corners relative to Pos when Angle is 0
+	force               GameEngine.V2D
+	torque              float64
+	stillFor            float64 // time spent barely moving
+	index               int     // place in world, for ordering
+}
+
+// NewCircleBody - dynamic circle of radius r centred on x, y. Mass comes from density times area
+func NewCircleBody(x, y, r, density float64) *Body {
+	b := &Body{Pos: GameEngine.P2D{X: x, Y: y}, radius: r, Restitution: 0.2, Friction: 0.4}
+	m := density * GameEngine.PI * r * r
+	b.setMass(m, m*r*r/2)
+	return b
+}
+
+// NewPolygonBody - dynamic convex polygon with corners points relative to x, y. The body is moved so Pos is the
+// centre of mass (the points are adjusted to match)
+func NewPolygonBody(x, y float64, points []GameEngine.P2D, density float64) *Body {
+	b := &Body{Restitution: 0.2, Friction: 0.4}
+	// area, centroid and inertia by splitting into triangles from the origin
+	var area, inertia float64
+	var cx, cy float64
+	for i := range points {
+		p, q := points[i], points[(i+1)%len(points)]
+		cross := p.X*q.Y - q.X*p.Y
+		area += cross / 2
+		cx += (p.X + q.X) * cross / 6
+		cy += (p.Y + q.Y) * cross / 6
+		inertia += cross / 12 * (p.X*p.X + p.X*q.X + q.X*q.X + p.Y*p.Y + p.Y*q.Y + q.Y*q.Y)
+	}
+	cx /= area
+	cy /= area
+	b.points = make([]GameEngine.P2D, len(points))
+	for i, p := range points {
+		b.points[i] = GameEngine.P2D{X: p.X - cx, Y: p.Y - cy}
+	}
+	b.Pos = GameEngine.P2D{X: x + cx, Y: y + cy}
+	m := density * math.Abs(area)
+	// inertia about the centroid (parallel axis theorem)
+	b.setMass(m, density*math.Abs(inertia)-m*(cx*cx+cy*cy))
+	return b
+}
+
+// NewBoxBody - dynamic w x h box centred on x, y
+func NewBoxBody(x, y, w, h, density float64) *Body {
+	return NewPolygonBody(x, y, []GameEngine.P2D{
+		{X: -w / 2, Y: -h / 2}, {X: w / 2, Y: -h / 2}, {X: w / 2, Y: h / 2}, {X: -w / 2, Y: h / 2},
+	}, density)
+}
+
+// Mass - mass of the body. Static and kinematic bodies act as if it was infinite
+func (b *Body) Mass() float64 {
+	return b.mass
+}
+
+// Shape - the body's collision shape in world coordinates
+func (b *Body) Shape() collision.Shape {
+	if b.radius > 0 {
+		return collision.Circle{C: b.Pos, R: b.radius}
+	}
+	ps := make([]GameEngine.P2D, len(b.points))
+	for i, p := range b.points {
+		ps[i] = b.Pos.Add(GameEngine.V2D{Dx: p.X, Dy: p.Y}.Rotate(b.Angle))
+	}
+	return collision.Polygon{Points: ps}
+}
+
+// ApplyForce - pushes the body's centre for the next step
+func (b *Body) ApplyForce(f GameEngine.V2D) {
+	b.force = b.force.Add(f)
+	b.Wake()
+}
+
+// ApplyTorque - spins the body for the next step
+func (b *Body) ApplyTorque(t float64) {
+	b.torque += t
+	b.Wake()
+}
+
+// ApplyImpulse - instant kick j at world point at, changing velocity and spin
+func (b *Body) ApplyImpulse(j GameEngine.V2D, at GameEngine.P2D) {
+	if b.invMass == 0 {
+		return
+	}
+	b.Vel = b.Vel.Add(j.Scale(b.invMass))
+	b.AngVel += at.Sub(b.Pos).Cross(j) * b.invInertia
+	b.Wake()
+}
+
+// Wake - starts a sleeping body moving again
+func (b *Body) Wake() {
+	b.Sleeping = false
+	b.stillFor = 0
+}
+
+// setMass - sets mass and rotational inertia
+func (b *Body) setMass(m, i float64) {
+	b.mass, b.inertia = m, i
+	b.invMass, b.invInertia = 0, 0
+	if m > 0 {
+		b.invMass = 1 / m
+	}
+	if i > 0 {
+		b.invInertia = 1 / i
+	}
+}
+
+// moving - true if the body can be pushed by collisions right now
+func (b *Body) moving() bool {
+	return b.Type == Dynamic && !b.Sleeping
+}
+
+// kinematic - true if the body is kinematic
+func (b *Body) kinematic() bool {
+	return b.Type == Kinematic
+}
+
+// still - true if the body is moving and turning slower than speed
+func (b *Body) still(speed float64) bool {
+	return b.Vel.Len() <= speed && math.Abs(b.AngVel) <= speed
+}
+
+// inverse mass and inertia as the solver sees them
+func (b *Body) invMasses() (float64, float64) {
+	if !b.moving() {
+		return 0, 0
+	}
+	return b.invMass, b.invInertia
+}
+
+// velocityAt - velocity of the point r from the centre
+func (b *Body) velocityAt(r GameEngine.V2D) GameEngine.V2D {
+	return b.Vel.Add(r.Perp().Scale(b.AngVel))
+}
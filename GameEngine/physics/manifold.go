@@ -0,0 +1,99 @@
+package physics
+
+import (
+	"math"
+
+	"github.com/kevincolyer/GameEngine/GameEngine"
+	"github.com/kevincolyer/GameEngine/GameEngine/collision"
+)
+
+// touch - a point where two shapes touch and how far they overlap there
+type touch struct {
+	at    GameEngine.P2D
+	depth float64
+}
+
+// manifold - where shapes sa and sb touch. Two polygons can touch at two points, found by clipping the edge of
+// one that faces the other against the sides of the other's facing edge (so a box lying flat is held up at both
+// ends). Anything with a circle touches at the single point from the overlap test
+func manifold(sa, sb collision.Shape, col collision.Contact) []touch {
+	pa, aPoly := sa.(collision.Polygon)
+	pb, bPoly := sb.(collision.Polygon)
+	if !aPoly || !bPoly {
+		return []touch{{col.Point, col.Depth}}
+	}
+	// the reference edge is the one that lines up best with the normal, the incident edge is the other
+	// polygon's edge that faces it
+	ref, refDot := facing(pa.Points, col.Normal)
+	inc, incDot := facing(pb.Points, col.Normal.Scale(-1))
+	refPoly, incPoly := pa.Points, pb.Points
+	if incDot > refDot+0.001 {
+		ref = inc
+		refPoly, incPoly = pb.Points, pa.Points
+	}
+	r0, r1 := refPoly[ref], refPoly[(ref+1)%len(refPoly)]
+	rn := edgeNormal(refPoly, ref)
+	inc, _ = facing(incPoly, rn.Scale(-1))
+	ps := []GameEngine.P2D{incPoly[inc], incPoly[(inc+1)%len(incPoly)]}
+	tol := 0.02 * ps[0].Dist(ps[1])
+	// cut to the sides of the reference edge
+	side := r1.Sub(r0).Normalise()
+	ps = clip(ps, side, dotP(r0, side))
+	ps = clip(ps, side.Scale(-1), -dotP(r1, side))
+	// and keep what is past the reference edge, or near enough that a slightly tipped box still rests on both ends
+	var out []touch
+	for _, p := range ps {
+		if sep := dotP(p, rn) - dotP(r0, rn); sep <= tol {
+			out = append(out, touch{p, -sep})
+		}
+	}
+	if len(out) == 0 {
+		return []touch{{col.Point, col.Depth}}
+	}
+	return out
+}
+
+// facing - index of the edge of ps whose outward normal points most along n, and how much
+func facing(ps []GameEngine.P2D, n GameEngine.V2D) (best int, most float64) {
+	most = math.Inf(-1)
+	for i := range ps {
+		if d := edgeNormal(ps, i).Dot(n); d > most {
+			best, most = i, d
+		}
+	}
+	return
+}
+
+// edgeNormal - outward unit normal of edge i of polygon ps, whichever way round its corners go
+func edgeNormal(ps []GameEngine.P2D, i int) GameEngine.V2D {
+	a, b := ps[i], ps[(i+1)%len(ps)]
+	n := b.Sub(a).Perp().Normalise()
+	// the far corner is inside, so the normal should point away from it
+	if n.Dot(ps[(i+2)%len(ps)].Sub(a)) > 0 {
+		n = n.Scale(-1)
+	}
+	return n
+}
+
+// clip - the part of segment ps where dotP(p, n) >= d
+func clip(ps []GameEngine.P2D, n GameEngine.V2D, d float64) (out []GameEngine.P2D) {
+	if len(ps) < 2 {
+		return ps
+	}
+	d0, d1 := dotP(ps[0], n)-d, dotP(ps[1], n)-d
+	if d0 >= 0 {
+		out = append(out, ps[0])
+	}
+	if d1 >= 0 {
+		out = append(out, ps[1])
+	}
+	if d0*d1 < 0 {
+		t := d0 / (d0 - d1)
+		out = append(out, ps[0].Add(ps[1].Sub(ps[0]).Scale(t)))
+	}
+	return
+}
+
+func dotP(p GameEngine.P2D, n GameEngine.V2D) float64 {
+	return p.X*n.Dx + p.Y*n.Dy
+}
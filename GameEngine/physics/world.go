@@ -0,0 +1,308 @@
+package physics
+
+import (
+	"math"
+	"sort"
+
+	"github.com/kevincolyer/GameEngine/GameEngine"
+	"github.com/kevincolyer/GameEngine/GameEngine/collision"
+)
+
+// World - holds the bodies and steps them. Everything is done in the order bodies were added, so the same
+// bodies given the same updates always end up in the same place
+type World struct {
+	Gravity    GameEngine.V2D
+	Step       float64 // fixed time step, in Elapsed units (1 is 10ms)
+	Iterations int     // solver passes per step. More is stiffer and slower
+	MaxSteps   int     // most steps one Update will take, so a slow frame can't snowball
+	SleepTime  float64 // how long a body must be nearly still before it sleeps. 0 never sleeps
+	SleepSpeed float64 // speed below which a body counts as still
+	Slop       float64 // overlap allowed before bodies are pushed apart, stops jitter
+	Push       float64 // how much of the overlap is pushed out each step, 0-1
+	// OnContact - if set, called for each touching pair every step, before the impulses are worked out
+	OnContact func(a, b *Body, c collision.Contact)
+
+	bodies []*Body
+	acc    float64
+	last   map[[2]*Body][]contactPoint // last step's contacts, to start the solver from
+}
+
+// contact - a touching pair being solved this step
+type contact struct {
+	a, b     *Body
+	n        GameEngine.V2D
+	friction float64
+	points   []contactPoint
+}
+
+// contactPoint - one of the places a pair touch. Boxes lying flat touch at two corners
+type contactPoint struct {
+	ra, rb         GameEngine.V2D // contact point from each centre
+	depth          float64
+	normalMass     float64
+	tangentMass    float64
+	bias           float64 // bounce velocity to aim for
+	normalImpulse  float64
+	tangentImpulse float64
+}
+
+// NewWorld - empty world with gravity g and sensible settings
+func NewWorld(g GameEngine.V2D) *World {
+	return &World{
+		Gravity:    g,
+		Step:       1,
+		Iterations: 8,
+		MaxSteps:   8,
+		SleepTime:  50,
+		SleepSpeed: 0.002,
+		Slop:       0.01,
+		Push:       0.2,
+	}
+}
+
+// Add - puts b in the world
+func (w *World) Add(b *Body) {
+	b.index = len(w.bodies)
+	w.bodies = append(w.bodies, b)
+}
+
+// Remove - takes b out of the world. Anything touching it is woken in case it was resting on it
+func (w *World) Remove(b *Body) {
+	for i, o := range w.bodies {
+		if o == b {
+			w.bodies = append(w.bodies[:i], w.bodies[i+1:]...)
+			break
+		}
+	}
+	box := b.Shape().Bounds()
+	for i, o := range w.bodies {
+		o.index = i
+		if o.Sleeping && o.Shape().Bounds().Overlaps(box) {
+			o.Wake()
+		}
+	}
+}
+
+// Bodies - the bodies in the world, in the order they were added
+func (w *World) Bodies() []*Body {
+	return w.bodies
+}
+
+// Update - runs as many fixed steps as fit in elapsed, carrying the rest over to the next call
+func (w *World) Update(elapsed float64) {
+	w.acc += elapsed
+	for n := 0; w.acc >= w.Step; n++ {
+		if n == w.MaxSteps {
+			w.acc = 0
+			return
+		}
+		w.StepOnce()
+		w.acc -= w.Step
+	}
+}
+
+// StepOnce - moves the world on by one fixed step
+func (w *World) StepOnce() {
+	dt := w.Step
+	// found before gravity is added, so bodies resting on each other look still enough to leave asleep
+	contacts := w.contacts()
+	for _, b := range w.bodies {
+		if !b.moving() {
+			continue
+		}
+		b.Vel = b.Vel.Add(w.Gravity.Add(b.force.Scale(b.invMass)).Scale(dt))
+		b.AngVel += b.torque * b.invInertia * dt
+		b.force, b.torque = GameEngine.V2D{}, 0
+	}
+	for _, c := range contacts {
+		c.prepare(w, dt)
+	}
+	for _, c := range contacts {
+		c.warmStart(w.last[[2]*Body{c.a, c.b}])
+	}
+	for i := 0; i < w.Iterations; i++ {
+		for _, c := range contacts {
+			c.solve()
+		}
+	}
+	w.last = make(map[[2]*Body][]contactPoint, len(contacts))
+	for _, c := range contacts {
+		w.last[[2]*Body{c.a, c.b}] = c.points
+	}
+	for _, b := range w.bodies {
+		if b.Type == Static || b.Sleeping {
+			continue
+		}
+		b.Pos = b.Pos.Add(b.Vel.Scale(dt))
+		b.Angle += b.AngVel * dt
+	}
+	w.sleep(dt)
+}
+
+// contacts - finds touching pairs with a sweep along x
+func (w *World) contacts() (out []*contact) {
+	type entry struct {
+		b     *Body
+		box   collision.AABB
+		shape collision.Shape
+	}
+	es := make([]entry, len(w.bodies))
+	for i, b := range w.bodies {
+		s := b.Shape()
+		es[i] = entry{b, s.Bounds(), s}
+	}
+	// stable so equal edges stay in the order bodies were added
+	sort.SliceStable(es, func(i, j int) bool { return es[i].box.Min.X < es[j].box.Min.X })
+	for i, ea := range es {
+		for _, eb := range es[i+1:] {
+			if eb.box.Min.X > ea.box.Max.X {
+				break
+			}
+			if !ea.box.Overlaps(eb.box) {
+				continue
+			}
+			a, b, sa, sb := ea.b, eb.b, ea.shape, eb.shape
+			if a.index > b.index {
+				a, b, sa, sb = b, a, sb, sa
+			}
+			if !a.moving() && !b.moving() && !a.kinematic() && !b.kinematic() {
+				continue
+			}
+			c, ok := collision.Overlap(sa, sb)
+			if !ok {
+				continue
+			}
+			// a sleeping body is only woken by something that is really moving, otherwise a pile would
+			// keep waking itself up
+			if a.Sleeping && b.Type != Static && !b.still(w.SleepSpeed) {
+				a.Wake()
+			}
+			if b.Sleeping && a.Type != Static && !a.still(w.SleepSpeed) {
+				b.Wake()
+			}
+			if !a.moving() && !b.moving() {
+				continue
+			}
+			if w.OnContact != nil {
+				w.OnContact(a, b, c)
+			}
+			out = append(out, newContact(a, b, sa, sb, c))
+		}
+	}
+	// pairs come out in sweep order, put them in body order so the solve doesn't depend on positions
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].a.index != out[j].a.index {
+			return out[i].a.index < out[j].a.index
+		}
+		return out[i].b.index < out[j].b.index
+	})
+	return
+}
+
+// newContact - contact between a and b touching at the points of their manifold
+func newContact(a, b *Body, sa, sb collision.Shape, col collision.Contact) *contact {
+	c := &contact{a: a, b: b, n: col.Normal}
+	c.friction = math.Sqrt(a.Friction * b.Friction)
+	for _, t := range manifold(sa, sb, col) {
+		c.points = append(c.points, contactPoint{ra: t.at.Sub(a.Pos), rb: t.at.Sub(b.Pos), depth: t.depth})
+	}
+	return c
+}
+
+// prepare - works out the bits of each contact point that don't change while solving, including the speed to
+// aim for to bounce and to push the bodies out of each other
+func (c *contact) prepare(w *World, dt float64) {
+	// slower than this and a hit doesn't bounce, or resting bodies would hop off the floor every step
+	bounce := math.Max(2*w.Gravity.Len()*dt, 0.01)
+	ima, iia := c.a.invMasses()
+	imb, iib := c.b.invMasses()
+	t := c.n.Perp()
+	for i := range c.points {
+		p := &c.points[i]
+		rna, rnb := p.ra.Cross(c.n), p.rb.Cross(c.n)
+		if k := ima + imb + rna*rna*iia + rnb*rnb*iib; k > 0 {
+			p.normalMass = 1 / k
+		}
+		rta, rtb := p.ra.Cross(t), p.rb.Cross(t)
+		if k := ima + imb + rta*rta*iia + rtb*rtb*iib; k > 0 {
+			p.tangentMass = 1 / k
+		}
+		p.bias = w.Push / dt * math.Max(p.depth-w.Slop, 0)
+		if vn := c.relative(p).Dot(c.n); vn < -bounce {
+			p.bias = math.Max(p.bias, -math.Max(c.a.Restitution, c.b.Restitution)*vn)
+		}
+	}
+}
+
+// warmStart - begins with the impulses the same points needed last step, which settles stacks far quicker than
+// starting from nothing each time
+func (c *contact) warmStart(last []contactPoint) {
+	t := c.n.Perp()
+	for i := range c.points {
+		p := &c.points[i]
+		for _, o := range last {
+			if p.ra.Sub(o.ra).Len() < 0.1*(p.ra.Len()+0.01) {
+				p.normalImpulse, p.tangentImpulse = o.normalImpulse, o.tangentImpulse
+				c.apply(p, c.n.Scale(p.normalImpulse).Add(t.Scale(p.tangentImpulse)))
+				break
+			}
+		}
+	}
+}
+
+// relative - velocity of b's contact point as seen from a's
+func (c *contact) relative(p *contactPoint) GameEngine.V2D {
+	return c.b.velocityAt(p.rb).Sub(c.a.velocityAt(p.ra))
+}
+
+// solve - one pass of the impulse solver. Impulses are summed over passes and clamped so the bodies only ever
+// push, and friction never beats the push
+func (c *contact) solve() {
+	t := c.n.Perp()
+	for i := range c.points {
+		p := &c.points[i]
+		vn := c.relative(p).Dot(c.n)
+		j := p.normalMass * (p.bias - vn)
+		old := p.normalImpulse
+		p.normalImpulse = math.Max(old+j, 0)
+		c.apply(p, c.n.Scale(p.normalImpulse-old))
+
+		vt := c.relative(p).Dot(t)
+		j = -p.tangentMass * vt
+		max := c.friction * p.normalImpulse
+		old = p.tangentImpulse
+		p.tangentImpulse = math.Max(-max, math.Min(old+j, max))
+		c.apply(p, t.Scale(p.tangentImpulse-old))
+	}
+}
+
+// apply - gives impulse j at point p to b and -j to a
+func (c *contact) apply(p *contactPoint, j GameEngine.V2D) {
+	ima, iia := c.a.invMasses()
+	imb, iib := c.b.invMasses()
+	c.a.Vel = c.a.Vel.Sub(j.Scale(ima))
+	c.a.AngVel -= p.ra.Cross(j) * iia
+	c.b.Vel = c.b.Vel.Add(j.Scale(imb))
+	c.b.AngVel += p.rb.Cross(j) * iib
+}
+
+// sleep - puts bodies that have been still long enough to sleep
+func (w *World) sleep(dt float64) {
+	if w.SleepTime <= 0 {
+		return
+	}
+	for _, b := range w.bodies {
+		if !b.moving() {
+			continue
+		}
+		if !b.still(w.SleepSpeed) {
+			b.stillFor = 0
+			continue
+		}
+		b.stillFor += dt
+		if b.stillFor >= w.SleepTime {
+			b.Sleeping = true
+			b.Vel, b.AngVel = GameEngine.V2D{}, 0
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package physics
+
+import (
+	"testing"
+
+	"github.com/kevincolyer/GameEngine/GameEngine"
+)
+
+// pile - a floor and two walls with boxes and balls thrown in, some already moving
+func pile() *World {
+	w := NewWorld(GameEngine.V2D{Dy: 0.01})
+	floor := NewBoxBody(0, 10, 40, 1, 1)
+	floor.Type = Static
+	w.Add(floor)
+	for _, x := range []float64{-10, 15} {
+		wall := NewBoxBody(x, 5, 1, 10, 1)
+		wall.Type = Static
+		w.Add(wall)
+	}
+	for i := 0; i < 5; i++ {
+		box := NewBoxBody(float64(i)*0.3-0.6, 8-float64(i)*1.05, 1, 1, 1)
+		box.AngVel = float64(i) * 0.01
+		w.Add(box)
+		ball := NewCircleBody(float64(i)*2+3, 2, 0.5, 1)
+		ball.Vel = GameEngine.V2D{Dx: float64(i-2) * 0.05}
+		w.Add(ball)
+	}
+	return w
+}
+
+func TestWorldDeterministic(t *testing.T) {
+	a, b := pile(), pile()
+	// uneven frame times, so steps are carried over between updates
+	frames := []float64{0.7, 1.3, 2.2, 0.4, 1}
+	for i := 0; i < 1000; i++ {
+		a.Update(frames[i%len(frames)])
+		b.Update(frames[i%len(frames)])
+	}
+	for i, ba := range a.Bodies() {
+		bb := b.Bodies()[i]
+		if ba.Pos != bb.Pos || ba.Vel != bb.Vel || ba.Angle != bb.Angle || ba.AngVel != bb.AngVel {
+			t.Fatalf("body %d: %+v %v differs from %+v %v", i, ba.Pos, ba.Angle, bb.Pos, bb.Angle)
+		}
+	}
+}
+
+func TestWorldStackSleeps(t *testing.T) {
+	w := NewWorld(GameEngine.V2D{Dy: 0.01})
+	floor := NewBoxBody(0, 10, 40, 1, 1)
+	floor.Type = Static
+	w.Add(floor)
+	const n = 4
+	for i := 0; i < n; i++ {
+		w.Add(NewBoxBody(0, 9-float64(i)*1.001, 1, 1, 1))
+	}
+	for i := 0; i < 3000; i++ {
+		w.StepOnce()
+	}
+	for i, b := range w.Bodies()[1:] {
+		if !b.Sleeping || b.Vel != (GameEngine.V2D{}) || b.AngVel != 0 {
+			t.Errorf("box %d still moving: %+v %v sleeping=%v", i, b.Vel, b.AngVel, b.Sleeping)
+		}
+		// still stacked up on the floor, whose top is at 9.5
+		want := 9 - float64(i)
+		if b.Pos.X < -0.05 || b.Pos.X > 0.05 || b.Pos.Y < want-0.05 || b.Pos.Y > want+0.05 {
+			t.Errorf("box %d at %+v, want about 0, %v", i, b.Pos, want)
+		}
+	}
+}
+
+func TestWorldStaticNeverMoves(t *testing.T) {
+	w := pile()
+	type place struct {
+		pos   GameEngine.P2D
+		angle float64
+	}
+	static := map[*Body]place{}
+	for _, b := range w.Bodies() {
+		if b.Type == Static {
+			b.ApplyForce(GameEngine.V2D{Dx: 1, Dy: -1})
+			b.ApplyTorque(1)
+			static[b] = place{b.Pos, b.Angle}
+		}
+	}
+	// something heavy and fast slammed into the floor
+	rock := NewBoxBody(0, 0, 3, 3, 50)
+	rock.Vel = GameEngine.V2D{Dy: 2}
+	w.Add(rock)
+	for i := 0; i < 500; i++ {
+		w.StepOnce()
+		for b, p := range static {
+			if b.Pos != p.pos || b.Angle != p.angle {
+				t.Fatalf("step %d: static body moved from %+v %v to %+v %v", i, p.pos, p.angle, b.Pos, b.Angle)
+			}
+		}
+	}
+}
@@ -0,0 +1,434 @@
+package GameEngine
+
+import (
+	"math"
+	"time"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// PostEffect - changes the finished frame before it is shown. frame is one pixel per block. elapsed is the time
+// since the last frame, in Elapsed units
+type PostEffect interface {
+	Apply(frame *RenderTarget, elapsed float64)
+}
+
+// SetPostEffects - runs effects, in order, on every frame at Present. They work on the frame buffer in memory, so
+// they work without a GPU, and turn it on if SetFrameBuffer turned it off. Effects are pointers, so change their fields
+// at any time to adjust them. Call with none to turn them off
+func (c *Context) SetPostEffects(effects ...PostEffect) {
+	c.effects = effects
+	c.updateFrame()
+}
+
+// updateFrame - makes the frame buffer if there are post effects, indexed colour or it was asked for, drops it if not
+func (c *Context) updateFrame() {
+	if len(c.effects) == 0 && c.indexed == nil && !c.keepFrame {
+		c.frame, c.post = nil, nil
+		return
+	}
+	if c.frame == nil {
+		c.frame = NewRenderTarget(c.ScrnWidth, c.ScrnHeight)
+		c.lastPresent = time.Now()
+	}
+}
+
+// PostEffects - the effects run at Present
+func (c *Context) PostEffects() []PostEffect {
+	return c.effects
+}
+
+// frameElapsed - time since the last Present of the frame buffer, in Elapsed units
+func (c *Context) frameElapsed() float64 {
+	t := time.Now()
+	elapsed := float64(t.Sub(c.lastPresent)) / (1000 * 1000 * 10)
+	c.lastPresent = t
+	return elapsed
+}
+
+// applyPostEffects - runs the effects on a copy of the frame (so frames that aren't cleared don't build them up)
+// and puts the result on the screen. With no effects the frame goes straight to the screen
+func (c *Context) applyPostEffects(elapsed float64) {
+	if len(c.effects) == 0 {
+		c.blit(c.frame)
+		return
+	}
+	copyOf(c.frame, &c.post)
+	for _, e := range c.effects {
+		e.Apply(c.post, elapsed)
+	}
+	c.post.mask = nil
+	c.blit(c.post)
+}
+
+// blit - copies t to the screen, a block per pixel. It goes up in one piece as a texture stretched over the window,
+// or if there isn't one a rectangle at a time
+func (c *Context) blit(t *RenderTarget) {
+	if c.texture != nil {
+		if pix, pitch, err := c.texture.Lock(nil); err == nil {
+			row := int(t.W) * 4
+			for y := 0; y < int(t.H); y++ {
+				copy(pix[y*pitch:y*pitch+row], t.img.Pix[y*row:(y+1)*row])
+			}
+			c.texture.Unlock()
+			c.Renderer.Copy(c.texture, nil, nil)
+			return
+		}
+	}
+	c.Renderer.SetDrawBlendMode(sdl.BLENDMODE_NONE)
+	w, h := int(t.W), int(t.H)
+	p := t.img.Pix
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := (y*w + x) * 4
+			c.Renderer.SetDrawColor(p[i], p[i+1], p[i+2], 255)
+			c.Renderer.FillRect(NewRect(float64(x)*c.Blocks, float64(y)*c.Blocks, c.Blocks, c.Blocks))
+		}
+	}
+	c.SetBlendMode(c.blend)
+	c.Renderer.SetDrawColor(c.colour.Unpack())
+}
+
+// pixels - reads and writes a target as float colours for effects
+type pixels struct {
+	t    *RenderTarget
+	w, h int
+}
+
+func newPixels(t *RenderTarget) pixels {
+	return pixels{t, int(t.W), int(t.H)}
+}
+
+func (p pixels) at(x, y int) Colour {
+	x = minInt(maxInt(x, 0), p.w-1)
+	y = minInt(maxInt(y, 0), p.h-1)
+	i := (y*p.w + x) * 4
+	s := p.t.img.Pix
+	return Colour{float64(s[i]), float64(s[i+1]), float64(s[i+2]), float64(s[i+3])}
+}
+
+// rows - calls fn for every row, shared out over goroutines. fn must only change its own row
+func (p pixels) rows(fn func(y int)) {
+	ParallelFor(p.h, func(from, to int) {
+		for y := from; y < to; y++ {
+			fn(y)
+		}
+	})
+}
+
+func (p pixels) set(x, y int, c Colour) {
+	i := (y*p.w + x) * 4
+	s := p.t.img.Pix
+	s[i], s[i+1], s[i+2], s[i+3] = c.Unpack()
+}
+
+// copyOf - a scratch copy of t, kept in *buf between frames
+func copyOf(t *RenderTarget, buf **RenderTarget) *RenderTarget {
+	if *buf == nil || (*buf).W != t.W || (*buf).H != t.H {
+		*buf = NewRenderTarget(t.W, t.H)
+	}
+	copy((*buf).img.Pix, t.img.Pix)
+	return *buf
+}
+
+// CRT - old television look. Scanlines darkens every other row by that fraction (0-1). Curvature bends the
+// picture like the glass of a tube, 0 for flat, about 0.1 for a lot
+type CRT struct {
+	Scanlines float64
+	Curvature float64
+	scratch   *RenderTarget
+}
+
+// Apply - PostEffect
+func (e *CRT) Apply(frame *RenderTarget, elapsed float64) {
+	p := newPixels(frame)
+	if e.Curvature != 0 {
+		src := newPixels(copyOf(frame, &e.scratch))
+		p.rows(func(y int) {
+			for x := 0; x < p.w; x++ {
+				// -1 to 1 across the screen, pushed out the further it is from the middle
+				nx := (float64(x)+0.5)/float64(p.w)*2 - 1
+				ny := (float64(y)+0.5)/float64(p.h)*2 - 1
+				r := 1 + e.Curvature*(nx*nx+ny*ny)
+				sx := ((nx*r)+1)/2*float64(p.w) - 0.5
+				sy := ((ny*r)+1)/2*float64(p.h) - 0.5
+				if sx < -0.5 || sy < -0.5 || sx > float64(p.w)-0.5 || sy > float64(p.h)-0.5 {
+					p.set(x, y, Colour{A: 255})
+					continue
+				}
+				p.set(x, y, src.at(int(math.Round(sx)), int(math.Round(sy))))
+			}
+		})
+	}
+	if e.Scanlines != 0 {
+		f := 1 - Clamp01(e.Scanlines)
+		p.rows(func(y int) {
+			if y%2 == 0 {
+				return
+			}
+			for x := 0; x < p.w; x++ {
+				p.set(x, y, p.at(x, y).Fade(f))
+			}
+		})
+	}
+}
+
+// Quantise - snaps every colour to the nearest in Palette
+type Quantise struct {
+	Palette []Colour
+}
+
+// Apply - PostEffect
+func (e *Quantise) Apply(frame *RenderTarget, elapsed float64) {
+	if len(e.Palette) == 0 {
+		return
+	}
+	p := newPixels(frame)
+	p.rows(func(y int) {
+		for x := 0; x < p.w; x++ {
+			p.set(x, y, nearestColour(e.Palette, p.at(x, y)))
+		}
+	})
+}
+
+// nearestColour - colour of pal closest to c
+func nearestColour(pal []Colour, c Colour) Colour {
+	return pal[NearestColour(pal, c)]
+}
+
+// NearestColour - index of the colour of pal closest to c, by red mean weighted distance (which is close to how
+// eyes see it). Alpha is ignored
+func NearestColour(pal []Colour, c Colour) (best int) {
+	bestD := math.Inf(1)
+	for i, q := range pal {
+		rm := (c.R + q.R) / 2
+		dr, dg, db := c.R-q.R, c.G-q.G, c.B-q.B
+		d := (2+rm/256)*dr*dr + 4*dg*dg + (2+(255-rm)/256)*db*db
+		if d < bestD {
+			best, bestD = i, d
+		}
+	}
+	return
+}
+
+// DitherMode - how Dither spreads out the error of snapping to the palette
+type DitherMode int
+
+const (
+	// DitherOrdered - a fixed 4x4 Bayer pattern. Steady from frame to frame
+	DitherOrdered DitherMode = iota
+	// DitherFloydSteinberg - error diffusion. Smoother, but shimmers when things move
+	DitherFloydSteinberg
+)
+
+// Dither - snaps colours to Palette, dithering to fake the colours in between. Spread is how far (0-255) ordered
+// dithering nudges colours, 0 for a sensible default
+type Dither struct {
+	Palette []Colour
+	Mode    DitherMode
+	Spread  float64
+}
+
+var bayer4 = [16]float64{0, 8, 2, 10, 12, 4, 14, 6, 3, 11, 1, 9, 15, 7, 13, 5}
+
+// Apply - PostEffect
+func (e *Dither) Apply(frame *RenderTarget, elapsed float64) {
+	if len(e.Palette) == 0 {
+		return
+	}
+	p := newPixels(frame)
+	if e.Mode == DitherOrdered {
+		spread := e.Spread
+		if spread == 0 {
+			spread = 256 / math.Cbrt(float64(len(e.Palette)))
+		}
+		p.rows(func(y int) {
+			for x := 0; x < p.w; x++ {
+				d := (bayer4[(y%4)*4+x%4]/16 - 0.5) * spread
+				c := p.at(x, y)
+				c.R, c.G, c.B = c.R+d, c.G+d, c.B+d
+				p.set(x, y, nearestColour(e.Palette, c))
+			}
+		})
+		return
+	}
+	// keep the error as floats so it isn't lost to rounding
+	errs := make([]Colour, p.w*(p.h+1)+1)
+	for y := 0; y < p.h; y++ {
+		for x := 0; x < p.w; x++ {
+			i := y*p.w + x
+			c := p.at(x, y)
+			c.R, c.G, c.B = c.R+errs[i].R, c.G+errs[i].G, c.B+errs[i].B
+			n := nearestColour(e.Palette, c)
+			p.set(x, y, Colour{n.R, n.G, n.B, c.A})
+			er, eg, eb := c.R-n.R, c.G-n.G, c.B-n.B
+			spread := func(j int, f float64) {
+				errs[j].R += er * f
+				errs[j].G += eg * f
+				errs[j].B += eb * f
+			}
+			if x+1 < p.w {
+				spread(i+1, 7.0/16)
+				spread(i+p.w+1, 1.0/16)
+			}
+			if x > 0 {
+				spread(i+p.w-1, 3.0/16)
+			}
+			spread(i+p.w, 5.0/16)
+		}
+	}
+}
+
+// Bloom - bright parts glow. Colours brighter than Threshold (0-255) are blurred by Radius blocks and added back
+// Strength times over
+type Bloom struct {
+	Threshold float64
+	Strength  float64
+	Radius    int
+	bright    []Colour
+	blurred   []Colour
+}
+
+// Apply - PostEffect
+func (e *Bloom) Apply(frame *RenderTarget, elapsed float64) {
+	p := newPixels(frame)
+	n := p.w * p.h
+	if len(e.bright) != n {
+		e.bright, e.blurred = make([]Colour, n), make([]Colour, n)
+	}
+	p.rows(func(y int) {
+		for x := 0; x < p.w; x++ {
+			c := p.at(x, y)
+			if c.Luma() > e.Threshold {
+				e.bright[y*p.w+x] = c
+			} else {
+				e.bright[y*p.w+x] = Colour{}
+			}
+		}
+	})
+	// box blur across then down
+	boxBlur(e.bright, e.blurred, p.w, p.h, e.Radius, 1, p.w)
+	boxBlur(e.blurred, e.bright, p.h, p.w, e.Radius, p.w, 1)
+	p.rows(func(y int) {
+		for x := 0; x < p.w; x++ {
+			c, b := p.at(x, y), e.bright[y*p.w+x]
+			p.set(x, y, Colour{c.R + b.R*e.Strength, c.G + b.G*e.Strength, c.B + b.B*e.Strength, c.A})
+		}
+	})
+}
+
+// boxBlur - blurs lines of length n from src into dst. step moves along a line, next to the next line
+func boxBlur(src, dst []Colour, n, lines, r, step, next int) {
+	f := 1 / float64(2*r+1)
+	ParallelFor(lines, func(from, to int) {
+		for l := from; l < to; l++ {
+			blurLine(src[l*next:], dst[l*next:], n, r, step, f)
+		}
+	})
+}
+
+// blurLine - one line of boxBlur, starting at the start of src and dst
+func blurLine(src, dst []Colour, n, r, step int, f float64) {
+	var sum Colour
+	at := func(i int) Colour {
+		return src[minInt(maxInt(i, 0), n-1)*step]
+	}
+	for i := -r; i <= r; i++ {
+		c := at(i)
+		sum.R, sum.G, sum.B = sum.R+c.R, sum.G+c.G, sum.B+c.B
+	}
+	for i := 0; i < n; i++ {
+		dst[i*step] = Colour{sum.R * f, sum.G * f, sum.B * f, 255}
+		a, b := at(i-r), at(i+r+1)
+		sum.R, sum.G, sum.B = sum.R-a.R+b.R, sum.G-a.G+b.G, sum.B-a.B+b.B
+	}
+}
+
+// ChromaticAberration - splits red and blue apart towards the edges of the screen, by up to Offset blocks
+type ChromaticAberration struct {
+	Offset  float64
+	scratch *RenderTarget
+}
+
+// Apply - PostEffect
+func (e *ChromaticAberration) Apply(frame *RenderTarget, elapsed float64) {
+	p := newPixels(frame)
+	src := newPixels(copyOf(frame, &e.scratch))
+	cx, cy := float64(p.w)/2, float64(p.h)/2
+	p.rows(func(y int) {
+		for x := 0; x < p.w; x++ {
+			dx := (float64(x) + 0.5 - cx) / cx * e.Offset
+			dy := (float64(y) + 0.5 - cy) / cy * e.Offset
+			c := src.at(x, y)
+			c.R = src.at(int(math.Round(float64(x)-dx)), int(math.Round(float64(y)-dy))).R
+			c.B = src.at(int(math.Round(float64(x)+dx)), int(math.Round(float64(y)+dy))).B
+			p.set(x, y, c)
+		}
+	})
+}
+
+// Vignette - darkens towards the corners. Radius (0-1, of the distance to a corner) is where it starts and
+// Strength (0-1) how dark the corners get
+type Vignette struct {
+	Radius   float64
+	Strength float64
+}
+
+// Apply - PostEffect
+func (e *Vignette) Apply(frame *RenderTarget, elapsed float64) {
+	p := newPixels(frame)
+	cx, cy := float64(p.w)/2, float64(p.h)/2
+	corner := math.Hypot(cx, cy)
+	p.rows(func(y int) {
+		for x := 0; x < p.w; x++ {
+			d := math.Hypot(float64(x)+0.5-cx, float64(y)+0.5-cy) / corner
+			if d <= e.Radius {
+				continue
+			}
+			t := Clamp01((d - e.Radius) / math.Max(1-e.Radius, 1e-9))
+			p.set(x, y, p.at(x, y).Fade(1-e.Strength*t*t))
+		}
+	})
+}
+
+// Fade - mixes the whole frame towards Col by Amount (0-1). Use it to fade to black, or call Flash for a burst
+// of colour that dies away by itself
+type Fade struct {
+	Col    Colour
+	Amount float64
+	flash  float64 // time left flashing
+	length float64
+}
+
+// Flash - starts the frame fully col and fades back to normal over duration
+func (e *Fade) Flash(col Colour, duration float64) {
+	e.Col = col
+	e.Amount = 1
+	e.flash, e.length = duration, duration
+}
+
+// Flashing - true while a Flash is dying away
+func (e *Fade) Flashing() bool {
+	return e.flash > 0
+}
+
+// Apply - PostEffect
+func (e *Fade) Apply(frame *RenderTarget, elapsed float64) {
+	if e.flash > 0 {
+		e.flash -= elapsed
+		e.Amount = Clamp01(e.flash / e.length)
+	}
+	if e.Amount <= 0 {
+		return
+	}
+	a := Clamp01(e.Amount)
+	p := newPixels(frame)
+	p.rows(func(y int) {
+		for x := 0; x < p.w; x++ {
+			c := p.at(x, y)
+			f := c.Lerp(e.Col, a)
+			f.A = c.A
+			p.set(x, y, f)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package GameEngine
+
+import (
+	"math"
+	"testing"
+)
+
+// within - a and b differ by no more than d in every channel
+func within(a, b Colour, d float64) bool {
+	return math.Abs(a.R-b.R) <= d && math.Abs(a.G-b.G) <= d && math.Abs(a.B-b.B) <= d && math.Abs(a.A-b.A) <= d
+}
+
+// filled - a w by h target all col
+func filled(w, h int, col Colour) *RenderTarget {
+	t := NewRenderTarget(float64(w), float64(h))
+	t.Clear(col)
+	return t
+}
+
+func TestFade(t *testing.T) {
+	rt := filled(4, 4, Colour{100, 0, 200, 255})
+	rt.Set(1, 1, Colour{100, 0, 200, 128})
+	(&Fade{Col: Colour{0, 0, 0, 255}, Amount: 0.5}).Apply(rt, 1)
+	if got := rt.Pixel(0, 0); !within(got, Colour{50, 0, 100, 255}, 1) {
+		t.Errorf("half way to black: got %v", got)
+	}
+	if got := rt.Pixel(1, 1); got.A != 128 {
+		t.Errorf("alpha changed: got %v", got)
+	}
+
+	rt = filled(4, 4, Colour{0, 0, 0, 255})
+	f := &Fade{}
+	f.Apply(rt, 1)
+	if got := rt.Pixel(0, 0); got != (Colour{0, 0, 0, 255}) || f.Flashing() {
+		t.Errorf("no fade changed the frame: got %v", got)
+	}
+	f.Flash(Colour{200, 200, 200, 255}, 10)
+	if !f.Flashing() {
+		t.Fatalf("not flashing")
+	}
+	f.Apply(rt, 5)
+	if got := rt.Pixel(2, 3); !within(got, Colour{100, 100, 100, 255}, 1) {
+		t.Errorf("half way through the flash: got %v", got)
+	}
+	rt.Clear(Colour{0, 0, 0, 255})
+	f.Apply(rt, 5)
+	if got := rt.Pixel(2, 3); got != (Colour{0, 0, 0, 255}) || f.Flashing() || f.Amount != 0 {
+		t.Errorf("flash over: got %v, amount %v", got, f.Amount)
+	}
+}
+
+func TestQuantise(t *testing.T) {
+	black, white, red := Colour{0, 0, 0, 255}, Colour{255, 255, 255, 255}, Colour{255, 0, 0, 255}
+	rt := filled(3, 1, Colour{30, 30, 30, 255})
+	rt.Set(1, 0, Colour{200, 180, 190, 255})
+	rt.Set(2, 0, Colour{220, 40, 30, 255})
+	(&Quantise{Palette: []Colour{black, white, red}}).Apply(rt, 1)
+	for x, want := range []Colour{black, white, red} {
+		if got := rt.Pixel(x, 0); got != want {
+			t.Errorf("%d: got %v, want %v", x, got, want)
+		}
+	}
+	// no palette leaves it alone
+	rt.Set(0, 0, Colour{30, 30, 30, 255})
+	(&Quantise{}).Apply(rt, 1)
+	if got := rt.Pixel(0, 0); got != (Colour{30, 30, 30, 255}) {
+		t.Errorf("empty palette: got %v", got)
+	}
+}
+
+func TestDitherOrdered(t *testing.T) {
+	black, white := Colour{0, 0, 0, 255}, Colour{255, 255, 255, 255}
+	d := &Dither{Palette: []Colour{black, white}, Mode: DitherOrdered}
+	rt := filled(8, 8, Colour{128, 128, 128, 255})
+	d.Apply(rt, 1)
+	// mid grey is half white, the brighter half of the Bayer pattern, repeating every 4 blocks
+	whites := 0
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			want := black
+			if bayer4[(y%4)*4+x%4] >= 8 {
+				want = white
+				whites++
+			}
+			if got := rt.Pixel(x, y); got != want {
+				t.Errorf("%d, %d: got %v, want %v", x, y, got, want)
+			}
+		}
+	}
+	if whites != 32 {
+		t.Errorf("got %d white", whites)
+	}
+	// colours in the palette stay as they are
+	rt = filled(4, 4, white)
+	rt.Set(1, 2, black)
+	d.Apply(rt, 1)
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			want := white
+			if x == 1 && y == 2 {
+				want = black
+			}
+			if got := rt.Pixel(x, y); got != want {
+				t.Errorf("%d, %d: got %v, want %v", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestVignette(t *testing.T) {
+	white := Colour{255, 255, 255, 255}
+	rt := filled(8, 8, white)
+	(&Vignette{Radius: 0.5, Strength: 1}).Apply(rt, 1)
+	if got := rt.Pixel(3, 4); got != white {
+		t.Errorf("centre changed: got %v", got)
+	}
+	// corners are 0.875 of the way out, 3/4 of the way into the darkening: 1-(3/4)^2 of white
+	for _, p := range [][2]int{{0, 0}, {7, 0}, {0, 7}, {7, 7}} {
+		if got := rt.Pixel(p[0], p[1]); !within(got, Colour{111.6, 111.6, 111.6, 255}, 1) {
+			t.Errorf("corner %v: got %v", p, got)
+		}
+	}
+	// edge middles are 0.625 out, 1-(1/4)^2 of white
+	if got := rt.Pixel(0, 3); !within(got, Colour{239, 239, 239, 255}, 1) {
+		t.Errorf("edge: got %v", got)
+	}
+
+	rt = filled(8, 8, white)
+	(&Vignette{Radius: 0.5}).Apply(rt, 1)
+	if got := rt.Pixel(0, 0); got != white {
+		t.Errorf("no strength changed the corner: got %v", got)
+	}
+}
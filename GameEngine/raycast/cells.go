@@ -0,0 +1,170 @@
+package raycast
+
+import (
+	"math"
+
+	"github.com/kevincolyer/GameEngine/GameEngine"
+)
+
+// CellKind - how the raycaster treats a cell
+type CellKind int
+
+const (
+	// Empty - nothing there
+	Empty CellKind = iota
+	// Wall - a solid block filling the cell
+	Wall
+	// ThinWall - a flat wall across the middle of the cell
+	ThinWall
+	// Door - a thin wall across the middle of the cell that slides open
+	Door
+)
+
+// CellType - what a cell ID in the map means
+type CellType struct {
+	Kind    CellKind
+	Texture *GameEngine.Sprite // nil uses the renderer's Textures.Wall
+	Height  float64            // in storeys, 1 is a normal wall. Taller walls stack the texture. 0 counts as 1
+	AlongX  bool               // thin walls and doors run along x (facing ±y) rather than along y
+}
+
+// DoorState - how far open a door cell is
+type DoorState struct {
+	Open    float64 // 0 closed to 1 open
+	Opening bool    // moving open if true, closed if false
+	Speed   float64 // amount of Open per unit of elapsed time
+}
+
+// Toggle - starts the door opening if it is closed or closing, closing if open or opening
+func (d *DoorState) Toggle() {
+	d.Opening = !d.Opening
+}
+
+// Type - the cell type for cell x, y. IDs with no type set are plain walls, 0 is empty
+func (m *Map) Type(x, y int) CellType {
+	id := m.At(x, y)
+	if t, ok := m.Types[id]; ok {
+		if t.Height == 0 {
+			t.Height = 1
+		}
+		return t
+	}
+	if id == 0 {
+		return CellType{Kind: Empty}
+	}
+	return CellType{Kind: Wall, Height: 1}
+}
+
+// SetType - sets what cell ID id means
+func (m *Map) SetType(id int, t CellType) {
+	if m.Types == nil {
+		m.Types = map[int]CellType{}
+	}
+	m.Types[id] = t
+	m.maxHeight = 0
+}
+
+// Door - state of the door at cell x, y or nil if it isn't a door
+func (m *Map) Door(x, y int) *DoorState {
+	if m.Type(x, y).Kind != Door {
+		return nil
+	}
+	if m.doors == nil {
+		m.doors = map[int]*DoorState{}
+	}
+	d, ok := m.doors[x+y*m.W]
+	if !ok {
+		d = &DoorState{Speed: 0.02}
+		m.doors[x+y*m.W] = d
+	}
+	return d
+}
+
+// doorOpen - how open the door at cell x, y is, without making its state, so rays can be cast from several
+// goroutines at once
+func (m *Map) doorOpen(x, y int) float64 {
+	if d, ok := m.doors[x+y*m.W]; ok {
+		return d.Open
+	}
+	return 0
+}
+
+// Update - moves opening and closing doors along
+func (m *Map) Update(elapsed float64) {
+	for _, d := range m.doors {
+		if d.Opening {
+			d.Open = math.Min(1, d.Open+d.Speed*elapsed)
+		} else {
+			d.Open = math.Max(0, d.Open-d.Speed*elapsed)
+		}
+	}
+}
+
+// blocks - true if you can't walk through cell x, y. Doors block until they are mostly open
+func (m *Map) blocks(x, y int) bool {
+	switch m.Type(x, y).Kind {
+	case Empty:
+		return false
+	case Door:
+		return m.Door(x, y).Open < 0.75
+	}
+	return true
+}
+
+// tallest - height of the tallest cell type in the map
+func (m *Map) tallest() float64 {
+	if m.maxHeight == 0 {
+		m.maxHeight = 1
+		for _, t := range m.Types {
+			m.maxHeight = math.Max(m.maxHeight, t.Height)
+		}
+	}
+	return m.maxHeight
+}
+
+// hitCell - given a ray from ox, oy along dx, dy entering a cell at h, returns where it hits something in the
+// cell. Walls are hit where the ray enters, thin walls and doors across the middle of the cell
+func (m *Map) hitCell(h Hit, ox, oy, dx, dy float64) (Hit, bool) {
+	t := m.Type(h.CellX, h.CellY)
+	switch t.Kind {
+	case Empty:
+		return h, false
+	case Wall:
+		return h, true
+	}
+	l := math.Hypot(dx, dy)
+	dx /= l
+	dy /= l
+	// swap x and y so the wall always runs along x in the middle of the cell
+	cx, cy := float64(h.CellX), float64(h.CellY)
+	if !t.AlongX {
+		ox, oy, dx, dy, cx, cy = oy, ox, dy, dx, cy, cx
+	}
+	if dy == 0 {
+		return h, false
+	}
+	dist := (cy + 0.5 - oy) / dy
+	if dist < h.Dist {
+		return h, false
+	}
+	u := ox + dx*dist - cx
+	if u < 0 || u >= 1 {
+		return h, false
+	}
+	if t.Kind == Door {
+		// door slides out of the cell towards +u
+		open := m.doorOpen(h.CellX, h.CellY)
+		if u < open {
+			return h, false
+		}
+		u -= open
+	}
+	hit := Hit{CellX: h.CellX, CellY: h.CellY, Dist: dist, U: u}
+	hit.X, hit.Y = ox+dx*dist, cy+0.5
+	hit.NormalY = -GameEngine.Sign(dy)
+	if !t.AlongX {
+		hit.X, hit.Y = hit.Y, hit.X
+		hit.NormalX, hit.NormalY = hit.NormalY, 0
+	}
+	return hit, true
+}
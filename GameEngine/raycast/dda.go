@@ -0,0 +1,121 @@
+package raycast
+
+import "math"
+
+// Hit - where a ray cast through a grid stopped
+type Hit struct {
+	CellX, CellY     int     // cell that was hit
+	Dist             float64 // distance from the ray origin to the hit point
+	X, Y             float64 // hit point
+	NormalX, NormalY float64 // face normal (one of ±1,0 or 0,±1) pointing back at the ray. 0,0 if it started inside
+	U                float64 // 0-1 across the face that was hit, for texturing
+}
+
+// Walk - steps a ray from ox, oy along dx, dy through unit grid cells (Amanatides-Woo DDA). visit is called
+// for every cell the ray touches in order, starting with the one it starts in, with where the ray entered the
+// cell. Walking stops when visit returns true or maxDist is passed. dx, dy need not be normalised. maxDist must
+// be finite, as a ray that nothing stops would walk forever: with Inf or NaN nothing is visited
+func Walk(ox, oy, dx, dy, maxDist float64, visit func(h Hit) bool) {
+	l := math.Hypot(dx, dy)
+	if l == 0 || math.IsInf(maxDist, 0) || math.IsNaN(maxDist) {
+		return
+	}
+	dx /= l
+	dy /= l
+	cx := int(math.Floor(ox))
+	cy := int(math.Floor(oy))
+	if visit(Hit{CellX: cx, CellY: cy, X: ox, Y: oy}) {
+		return
+	}
+
+	// distance along the ray to cross one whole cell in x and in y
+	deltaX := math.Inf(1)
+	if dx != 0 {
+		deltaX = math.Abs(1 / dx)
+	}
+	deltaY := math.Inf(1)
+	if dy != 0 {
+		deltaY = math.Abs(1 / dy)
+	}
+	// distance along the ray to the first x and y cell boundaries
+	stepX, stepY := 1, 1
+	nextX := (float64(cx) + 1 - ox) * deltaX
+	if dx < 0 {
+		stepX = -1
+		nextX = (ox - float64(cx)) * deltaX
+	}
+	nextY := (float64(cy) + 1 - oy) * deltaY
+	if dy < 0 {
+		stepY = -1
+		nextY = (oy - float64(cy)) * deltaY
+	}
+
+	for {
+		var h Hit
+		xSide := nextX < nextY
+		if xSide {
+			h.Dist = nextX
+			nextX += deltaX
+			cx += stepX
+		} else {
+			h.Dist = nextY
+			nextY += deltaY
+			cy += stepY
+		}
+		if h.Dist > maxDist {
+			return
+		}
+		h.CellX, h.CellY = cx, cy
+		h.X, h.Y = ox+dx*h.Dist, oy+dy*h.Dist
+		if xSide {
+			h.NormalX = float64(-stepX)
+			h.U = h.Y - math.Floor(h.Y)
+			if dx > 0 {
+				h.U = 1 - h.U
+			}
+		} else {
+			h.NormalY = float64(-stepY)
+			h.U = h.X - math.Floor(h.X)
+			if dy < 0 {
+				h.U = 1 - h.U
+			}
+		}
+		if visit(h) {
+			return
+		}
+	}
+}
+
+// Cast - casts a ray through unit grid cells (see Walk) until solid returns true for a cell or maxDist is
+// passed. Returns the hit and true, or false if nothing was hit in range
+func Cast(ox, oy, dx, dy, maxDist float64, solid func(x, y int) bool) (hit Hit, ok bool) {
+	Walk(ox, oy, dx, dy, maxDist, func(h Hit) bool {
+		if solid(h.CellX, h.CellY) {
+			hit, ok = h, true
+		}
+		return ok
+	})
+	return
+}
+
+// Cast - casts a ray through the map stopping at the first wall, closed part of a door or thin wall
+func (m *Map) Cast(ox, oy, dx, dy, maxDist float64) (hit Hit, ok bool) {
+	Walk(ox, oy, dx, dy, maxDist, func(h Hit) bool {
+		hit, ok = m.hitCell(h, ox, oy, dx, dy)
+		return ok
+	})
+	if hit.Dist > maxDist {
+		return Hit{}, false
+	}
+	return
+}
+
+// LineOfSight - true if there are no walls between x0, y0 and x1, y1
+func (m *Map) LineOfSight(x0, y0, x1, y1 float64) bool {
+	dist := math.Hypot(x1-x0, y1-y0)
+	if dist == 0 {
+		return !m.Solid(x0, y0)
+	}
+	_, hit := m.Cast(x0, y0, x1-x0, y1-y0, dist)
+	return !hit
+}
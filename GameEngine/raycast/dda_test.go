@@ -0,0 +1,188 @@
+package raycast
+
+import (
+	"math"
+	"testing"
+)
+
+// near - a and b equal but for rounding
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// only - solid for the cells listed
+func only(cells ...[2]int) func(x, y int) bool {
+	return func(x, y int) bool {
+		for _, c := range cells {
+			if c[0] == x && c[1] == y {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func TestCastAxisAligned(t *testing.T) {
+	tests := []struct {
+		name             string
+		ox, oy, dx, dy   float64
+		solid            [2]int
+		cellX, cellY     int
+		dist             float64
+		normalX, normalY float64
+		u                float64
+	}{
+		{"right", 0.5, 0.25, 1, 0, [2]int{3, 0}, 3, 0, 2.5, -1, 0, 0.75},
+		{"left", 3.5, 0.25, -1, 0, [2]int{0, 0}, 0, 0, 2.5, 1, 0, 0.25},
+		{"down", 0.25, 0.5, 0, 1, [2]int{0, 3}, 0, 3, 2.5, 0, -1, 0.25},
+		{"up", 0.25, 3.5, 0, -1, [2]int{0, 0}, 0, 0, 2.5, 0, 1, 0.75},
+		{"not normalised", 0.5, 0.25, 10, 0, [2]int{3, 0}, 3, 0, 2.5, -1, 0, 0.75},
+	}
+	for _, tt := range tests {
+		h, ok := Cast(tt.ox, tt.oy, tt.dx, tt.dy, 10, only(tt.solid))
+		if !ok {
+			t.Errorf("%s: no hit", tt.name)
+			continue
+		}
+		if h.CellX != tt.cellX || h.CellY != tt.cellY || !near(h.Dist, tt.dist) ||
+			h.NormalX != tt.normalX || h.NormalY != tt.normalY || !near(h.U, tt.u) {
+			t.Errorf("%s: got %+v", tt.name, h)
+		}
+		l := math.Hypot(tt.dx, tt.dy)
+		if !near(h.X, tt.ox+tt.dx/l*tt.dist) || !near(h.Y, tt.oy+tt.dy/l*tt.dist) {
+			t.Errorf("%s: hit point %v, %v", tt.name, h.X, h.Y)
+		}
+	}
+}
+
+func TestWalkDiagonalCorners(t *testing.T) {
+	// a 45 degree ray from the middle of a cell goes exactly through the corners. It steps in y then x at each
+	type step struct {
+		x, y int
+		dist float64
+	}
+	want := []step{{0, 0, 0}, {0, 1, 0.5}, {1, 1, 0.5}, {1, 2, 1.5}, {2, 2, 1.5}}
+	var got []step
+	Walk(0.5, 0.5, 1, 1, 10, func(h Hit) bool {
+		got = append(got, step{h.CellX, h.CellY, h.Dist / math.Sqrt2})
+		return len(got) == len(want)
+	})
+	if len(got) != len(want) {
+		t.Fatalf("got %v", got)
+	}
+	for i := range want {
+		if got[i].x != want[i].x || got[i].y != want[i].y || !near(got[i].dist, want[i].dist) {
+			t.Fatalf("step %d: got %v want %v", i, got[i], want[i])
+		}
+	}
+
+	tests := []struct {
+		name             string
+		ox, oy, dx, dy   float64
+		solid            [2]int
+		normalX, normalY float64
+	}{
+		// stepping y first the ray goes through the cell beside the diagonal, and comes into it through an x face
+		{"down right", 0.5, 0.5, 1, 1, [2]int{1, 1}, -1, 0},
+		{"up left", 2.5, 2.5, -1, -1, [2]int{1, 1}, 1, 0},
+		// the cell beside the diagonal is hit first, through a y face
+		{"beside", 0.5, 0.5, 1, 1, [2]int{0, 1}, 0, -1},
+	}
+	for _, tt := range tests {
+		h, ok := Cast(tt.ox, tt.oy, tt.dx, tt.dy, 10, only(tt.solid))
+		if !ok || h.CellX != tt.solid[0] || h.CellY != tt.solid[1] || !near(h.Dist, math.Sqrt2/2) ||
+			h.NormalX != tt.normalX || h.NormalY != tt.normalY {
+			t.Errorf("%s: got %+v %v", tt.name, h, ok)
+			continue
+		}
+		// through a corner, so at one edge of the face or the other
+		if math.Min(h.U, 1-h.U) > 1e-9 {
+			t.Errorf("%s: U %v not at the corner", tt.name, h.U)
+		}
+		if !near(h.X, math.Round(h.X)) || !near(h.Y, math.Round(h.Y)) {
+			t.Errorf("%s: hit point %v, %v not a corner", tt.name, h.X, h.Y)
+		}
+	}
+}
+
+func TestCastStartsInside(t *testing.T) {
+	h, ok := Cast(1.5, 1.25, 1, 0, 10, only([2]int{1, 1}))
+	if !ok || h.CellX != 1 || h.CellY != 1 || h.Dist != 0 || h.NormalX != 0 || h.NormalY != 0 ||
+		h.X != 1.5 || h.Y != 1.25 {
+		t.Fatalf("got %+v %v", h, ok)
+	}
+}
+
+func TestCastLimits(t *testing.T) {
+	never := func(x, y int) bool { return false }
+	if _, ok := Cast(0.5, 0.5, 1, 0, 2, only([2]int{3, 0})); ok {
+		t.Error("hit past maxDist")
+	}
+	if _, ok := Cast(0.5, 0.5, 0, 0, 10, only([2]int{0, 0})); ok {
+		t.Error("hit with no direction")
+	}
+	// these would never stop if walked
+	if _, ok := Cast(0.5, 0.5, 1, 0, math.Inf(1), never); ok {
+		t.Error("hit with Inf maxDist")
+	}
+	if _, ok := Cast(0.5, 0.5, 1, 0, math.NaN(), never); ok {
+		t.Error("hit with NaN maxDist")
+	}
+}
+
+func testMap() *Map {
+	return NewMap([][]int{
+		{1, 1, 1, 1, 1},
+		{1, 0, 0, 0, 1},
+		{1, 0, 1, 0, 1},
+		{1, 0, 0, 0, 1},
+		{1, 1, 1, 1, 1},
+	})
+}
+
+func TestMapCast(t *testing.T) {
+	m := testMap()
+	h, ok := m.Cast(1.5, 1.5, 1, 0, 10)
+	if !ok || h.CellX != 4 || h.CellY != 1 || !near(h.Dist, 2.5) || h.NormalX != -1 || !near(h.U, 0.5) {
+		t.Errorf("across: got %+v %v", h, ok)
+	}
+	h, ok = m.Cast(1.5, 1.5, 1, 1, 10)
+	if !ok || h.CellX != 2 || h.CellY != 2 || !near(h.Dist, math.Sqrt2/2) || h.NormalX != -1 {
+		t.Errorf("diagonal: got %+v %v", h, ok)
+	}
+	h, ok = m.Cast(1.5, 3.5, 0, -1, 10)
+	if !ok || h.CellX != 1 || h.CellY != 0 || !near(h.Dist, 2.5) || h.NormalY != 1 {
+		t.Errorf("up: got %+v %v", h, ok)
+	}
+	if _, ok = m.Cast(1.5, 1.5, 1, 0, 2); ok {
+		t.Error("hit past maxDist")
+	}
+	// outside the map counts as wall
+	m = NewMap([][]int{{0, 0}})
+	h, ok = m.Cast(0.5, 0.5, 1, 0, 10)
+	if !ok || h.CellX != 2 || !near(h.Dist, 1.5) {
+		t.Errorf("off the map: got %+v %v", h, ok)
+	}
+}
+
+func TestLineOfSight(t *testing.T) {
+	m := testMap()
+	tests := []struct {
+		x0, y0, x1, y1 float64
+		want           bool
+	}{
+		{1.5, 1.5, 3.5, 1.5, true},
+		{1.5, 1.5, 1.5, 3.5, true},
+		{1.5, 2.5, 3.5, 2.5, false},
+		{1.5, 1.5, 3.5, 3.5, false},
+		{1.5, 1.5, 1.5, 1.5, true},
+		{2.5, 2.5, 2.5, 2.5, false},
+		// stops short of the wall
+		{1.2, 2.5, 1.9, 2.5, true},
+	}
+	for _, tt := range tests {
+		if got := m.LineOfSight(tt.x0, tt.y0, tt.x1, tt.y1); got != tt.want {
+			t.Errorf("%v,%v to %v,%v: got %v", tt.x0, tt.y0, tt.x1, tt.y1, got)
+		}
+	}
+}
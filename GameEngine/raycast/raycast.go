@@ -0,0 +1,296 @@
+// Package raycast - a Wolfenstein style raycaster for GameEngine. Renders textured walls, shaded floor and
+// ceiling and billboard sprites from a grid map
+package raycast
+
+import (
+	"math"
+
+	"github.com/kevincolyer/GameEngine/GameEngine"
+)
+
+// Map - grid of cell IDs indexed y then x. 0 is empty and anything else is a wall unless Types says otherwise
+type Map struct {
+	W, H      int
+	Cells     []int
+	Types     map[int]CellType
+	doors     map[int]*DoorState
+	maxHeight float64
+}
+
+// NewMap - builds a map from rows of cells (y then x). All rows must be the same length
+func NewMap(rows [][]int) *Map {
+	m := &Map{H: len(rows)}
+	if m.H > 0 {
+		m.W = len(rows[0])
+	}
+	m.Cells = make([]int, 0, m.W*m.H)
+	for _, row := range rows {
+		m.Cells = append(m.Cells, row[:m.W]...)
+	}
+	return m
+}
+
+// At - cell at x, y. Outside the map counts as wall (1)
+func (m *Map) At(x, y int) int {
+	if x < 0 || y < 0 || x >= m.W || y >= m.H {
+		return 1
+	}
+	return m.Cells[x+y*m.W]
+}
+
+// Set - sets cell x, y. Ignored outside the map
+func (m *Map) Set(x, y, cell int) {
+	if x < 0 || y < 0 || x >= m.W || y >= m.H {
+		return
+	}
+	m.Cells[x+y*m.W] = cell
+}
+
+// Solid - true if world position x, y is inside a wall or a door that isn't open
+func (m *Map) Solid(x, y float64) bool {
+	return m.blocks(int(math.Floor(x)), int(math.Floor(y)))
+}
+
+// TryMove - moves from x, y towards nx, ny. Each axis is tried separately so you slide along walls.
+// Returns the new position and true if a wall got in the way
+func (m *Map) TryMove(x, y, nx, ny float64) (float64, float64, bool) {
+	bumped := false
+	if m.Solid(nx, y) {
+		nx = x
+		bumped = true
+	}
+	if m.Solid(nx, ny) {
+		ny = y
+		bumped = true
+	}
+	return nx, ny, bumped
+}
+
+// Camera - eye position and looking angle in map cells. Angle 0 looks along +y, PI/2 along +x
+type Camera struct {
+	X, Y  float64
+	Angle float64
+	FOV   float64
+}
+
+// Dir - unit vector the camera is looking along
+func (cam Camera) Dir() (dx, dy float64) {
+	return math.Sin(cam.Angle), math.Cos(cam.Angle)
+}
+
+// Textures - what walls, floor and ceiling look like. Floor and ceiling textures are used instead of the
+// colours if they are set
+type Textures struct {
+	Wall           *GameEngine.Sprite
+	Floor          GameEngine.Colour // faded towards black at the horizon
+	Ceiling        GameEngine.Colour
+	FloorTexture   *GameEngine.Sprite
+	CeilingTexture *GameEngine.Sprite
+}
+
+// Billboard - a sprite standing on the floor at x, y that always faces the camera
+type Billboard struct {
+	Sprite *GameEngine.Sprite
+	X, Y   float64
+}
+
+// Renderer - draws a Map into a Context
+type Renderer struct {
+	Map       *Map
+	Textures  Textures
+	Horizon   float64           // rays give up after this distance
+	ScreenZ   float64           // distance of the screen in front of the eye
+	Fog       float64           // distance at which everything has faded to FogColour. 0 for no fog
+	FogColour GameEngine.Colour // black unless set
+	depth     []float64
+}
+
+// New - builds a renderer for map m
+func New(m *Map, tex Textures) *Renderer {
+	return &Renderer{
+		Map:      m,
+		Textures: tex,
+		Horizon:  15,
+		ScreenZ:  0.5,
+	}
+}
+
+// Render - draws walls, floor, ceiling and then billboards as seen from cam, filling the whole screen (or render
+// target). Everything is drawn a column per goroutine straight onto the blocks, so the screen transform and camera
+// aren't used
+func (r *Renderer) Render(c *GameEngine.Context, cam Camera, billboards []Billboard) {
+	w, _ := c.SurfaceSize()
+	if len(r.depth) != w {
+		r.depth = make([]float64, w)
+	}
+	r.drawWalls(c, cam)
+	r.drawBillboards(c, cam, billboards)
+}
+
+// Depth - distance to the wall or billboard drawn in each column by the last Render
+func (r *Renderer) Depth() []float64 {
+	return r.depth
+}
+
+// drawWalls - casts a ray for each column of the screen and draws the floor and ceiling then the walls it
+// passed from furthest to nearest so taller walls show over shorter ones
+func (r *Renderer) drawWalls(c *GameEngine.Context, cam Camera) {
+	fov2 := cam.FOV / 2
+	tallest := r.Map.tallest()
+
+	c.ParallelColumns(func(s *GameEngine.Surface, x int) {
+		bx, w := float64(x), float64(s.W)
+		a := cam.Angle + bx/w*cam.FOV - fov2
+		dx, dy := math.Sin(a), math.Cos(a)
+		r.drawFloorAndCeiling(s, cam, bx, dx, dy)
+
+		// collect walls until one is tall enough to hide everything behind it
+		var hits []Hit
+		Walk(cam.X, cam.Y, dx, dy, r.Horizon, func(h Hit) bool {
+			hit, ok := r.Map.hitCell(h, cam.X, cam.Y, dx, dy)
+			if !ok {
+				return false
+			}
+			hits = append(hits, hit)
+			outside := h.CellX < 0 || h.CellY < 0 || h.CellX >= r.Map.W || h.CellY >= r.Map.H
+			return outside || r.Map.Type(h.CellX, h.CellY).Height >= tallest
+		})
+
+		// z is distance to the nearest wall. Horizon if none
+		r.depth[x] = r.Horizon
+		for i := len(hits) - 1; i >= 0; i-- {
+			z := math.Max(hits[i].Dist, r.ScreenZ)
+			r.drawWallSlice(s, bx, z, hits[i])
+			r.depth[x] = z
+		}
+	})
+}
+
+// drawWallSlice - draws one column of a wall at distance z. Eye height is half a storey
+func (r *Renderer) drawWallSlice(s *GameEngine.Surface, bx, z float64, hit Hit) {
+	h := float64(s.H)
+	screenmid := h / 2
+	t := r.Map.Type(hit.CellX, hit.CellY)
+	tex := t.Texture
+	if tex == nil {
+		tex = r.Textures.Wall
+	}
+	storey := h / z // screen height of one storey
+	wallt := math.Trunc(screenmid - storey*(t.Height-0.5))
+	wallb := math.Trunc(screenmid + storey/2)
+	for by := math.Max(0, wallt); by < math.Min(h, wallb); by++ {
+		// Texture Draw - one copy of the texture per storey
+		ny := (by - wallt) / (wallb - wallt) * t.Height
+		s.Set(int(bx), int(by), r.fog(tex.SampleSprite(hit.U, ny), z))
+	}
+}
+
+// drawFloorAndCeiling - fills a whole column with floor below the middle of the screen and ceiling above it
+func (r *Renderer) drawFloorAndCeiling(s *GameEngine.Surface, cam Camera, bx, dx, dy float64) {
+	h := float64(s.H)
+	screenmid := h / 2
+	for by := 0.0; by < h; by++ {
+		// distance along the ray to the floor (or ceiling) point seen at this row
+		z := 0.5 * h / math.Abs(by+0.5-screenmid)
+		fx, fy := cam.X+dx*z, cam.Y+dy*z
+		fx, fy = fx-math.Floor(fx), fy-math.Floor(fy)
+		var col GameEngine.Colour
+		if by < screenmid {
+			if r.Textures.CeilingTexture != nil {
+				col = r.fog(r.Textures.CeilingTexture.SampleSprite(fx, fy), z)
+			} else {
+				col = r.fog(r.Textures.Ceiling, z)
+			}
+		} else {
+			if r.Textures.FloorTexture != nil {
+				col = r.fog(r.Textures.FloorTexture.SampleSprite(fx, fy), z)
+			} else if r.Fog > 0 {
+				col = r.fog(r.Textures.Floor, z)
+			} else {
+				col = r.Textures.Floor.Fade(1 - (h-by)/screenmid)
+			}
+		}
+		s.Set(int(bx), int(by), col)
+	}
+}
+
+// fog - fades col towards the fog colour with distance z if fog is on
+func (r *Renderer) fog(col GameEngine.Colour, z float64) GameEngine.Colour {
+	if r.Fog <= 0 {
+		return col
+	}
+	f := col.Lerp(r.FogColour, GameEngine.Clamp01(z/r.Fog))
+	f.A = col.A
+	return f
+}
+
+// billboard - where a billboard in view is drawn
+type billboard struct {
+	sprite        *GameEngine.Sprite
+	z             float64 // distance
+	left, top     float64 // top left corner on the screen
+	width, height float64
+}
+
+// drawBillboards - draws sprites in view scaled by distance, hidden behind nearer walls and billboards. Each column
+// goes through the billboards in order, so a billboard hides ones after it that are further away
+func (r *Renderer) drawBillboards(c *GameEngine.Context, cam Camera, billboards []Billboard) {
+	sw, sh := c.SurfaceSize()
+	w, h := float64(sw), float64(sh)
+	screenmid := h / 2
+	fov2 := cam.FOV / 2
+	eyex, eyey := cam.Dir()
+
+	var shown []billboard
+	for _, o := range billboards {
+		// is object in field of view?
+		oVecx := o.X - cam.X
+		oVecy := o.Y - cam.Y
+		z := math.Sqrt(oVecx*oVecx + oVecy*oVecy)
+		oAngle := math.Atan2(eyey, eyex) - math.Atan2(oVecy, oVecx)
+		if oAngle < -GameEngine.PI {
+			oAngle += GameEngine.PI * 2
+		}
+		if oAngle > GameEngine.PI {
+			oAngle -= GameEngine.PI * 2
+		}
+		if math.Abs(oAngle) >= fov2 || z < r.ScreenZ || z >= r.Horizon {
+			continue
+		}
+		oCeil := screenmid - (h / z)
+		oFloor := h - oCeil
+		oHeight := oFloor - oCeil
+		oWidth := oHeight / (o.Sprite.H / o.Sprite.W)
+		oMidObject := (0.5*(oAngle/fov2) + 0.5) * w
+		shown = append(shown, billboard{o.Sprite, z, oMidObject - oWidth/2, oCeil, oWidth, oHeight})
+	}
+	if len(shown) == 0 {
+		return
+	}
+
+	c.ParallelColumns(func(s *GameEngine.Surface, x int) {
+		for _, o := range shown {
+			// sampled through the middle of the column
+			lx := float64(x) + 0.5 - o.left
+			if lx < 0 || lx >= o.width || r.depth[x] < o.z {
+				continue
+			}
+			drawn := false
+			top, bottom := math.Max(0, math.Floor(o.top)), math.Min(float64(s.H), math.Ceil(o.top+o.height))
+			for by := top; by < bottom; by++ {
+				ly := by + 0.5 - o.top
+				if ly < 0 || ly >= o.height {
+					continue
+				}
+				clr := o.sprite.SampleSprite(lx/o.width, ly/o.height)
+				if clr.A > 0 {
+					s.Set(x, int(by), r.fog(clr, o.z))
+					drawn = true
+				}
+			}
+			if drawn {
+				r.depth[x] = o.z
+			}
+		}
+	})
+}
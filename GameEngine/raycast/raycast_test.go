@@ -0,0 +1,43 @@
+package raycast
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kevincolyer/GameEngine/GameEngine"
+)
+
+func TestRenderToTarget(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for i := range img.Pix {
+		img.Pix[i] = 200
+	}
+	spr := &GameEngine.Sprite{Image: img, W: 4, H: 4}
+	r := New(testMap(), Textures{
+		Wall:    spr,
+		Floor:   GameEngine.Colour{R: 50, A: 255},
+		Ceiling: GameEngine.Colour{B: 50, A: 255},
+	})
+	r.Fog = 8
+	c := &GameEngine.Context{Blocks: 4, ScrnWidth: 40, ScrnHeight: 20}
+	// not the size of the screen: all of it is drawn, the whole field of view across its width
+	rt := GameEngine.NewRenderTarget(30, 12)
+	c.SetRenderTarget(rt)
+	cam := Camera{X: 1.5, Y: 1.5, Angle: GameEngine.PI / 4, FOV: 1.5}
+	r.Render(c, cam, []Billboard{{Sprite: spr, X: 3.5, Y: 3.5}})
+	if len(r.Depth()) != 30 {
+		t.Fatalf("got %d depths, want 30", len(r.Depth()))
+	}
+	for y := 0; y < 12; y++ {
+		for x := 0; x < 30; x++ {
+			if rt.Pixel(x, y).A == 0 {
+				t.Fatalf("%d, %d not drawn", x, y)
+			}
+		}
+	}
+	// the middle column looks at the wall in the middle of the map, the edges past it
+	mid := r.Depth()[15]
+	if mid > 1.5 || r.Depth()[0] <= mid || r.Depth()[29] <= mid {
+		t.Errorf("got depths %v", r.Depth())
+	}
+}
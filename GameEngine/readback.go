@@ -0,0 +1,164 @@
+package GameEngine
+
+import (
+	"image"
+	"math"
+)
+
+// SetFrameBuffer - with on (as it starts), everything is drawn into a frame buffer in memory (a pixel per block)
+// and put on the screen in one go at Present, so what has been drawn can be read back with GetPoint, FloodFill
+// and Snapshot. Off draws straight to the screen a block at a time. Post effects and indexed colour use the frame
+// buffer whatever this is set to
+func (c *Context) SetFrameBuffer(on bool) {
+	c.keepFrame = on
+	c.updateFrame()
+}
+
+// surface - what is being drawn to, a colour a block: the render target, the indexed frame or the frame buffer.
+// ok is false when drawing straight to the screen, which can't be read
+func (c *Context) surface() (w, h int, at func(x, y int) Colour, ok bool) {
+	switch {
+	case c.target != nil:
+		return int(c.target.W), int(c.target.H), rgbaAt(c.target), true
+	case c.indexed != nil:
+		f := c.indexed
+		return f.w, f.h, func(x, y int) Colour {
+			if i := int(f.pix[y*f.w+x]); i < len(f.pal) {
+				return f.pal[i]
+			}
+			return Colour{A: 255}
+		}, true
+	case c.frame != nil:
+		return int(c.frame.W), int(c.frame.H), rgbaAt(c.frame), true
+	}
+	return 0, 0, nil, false
+}
+
+// rgbaAt - reads pixels of t, which must be inside it
+func rgbaAt(t *RenderTarget) func(x, y int) Colour {
+	w, p := int(t.W), t.img.Pix
+	return func(x, y int) Colour {
+		i := (y*w + x) * 4
+		return Colour{float64(p[i]), float64(p[i+1]), float64(p[i+2]), float64(p[i+3])}
+	}
+}
+
+// toSurface - the pixel of the surface that Point(x, y) draws to
+func (c *Context) toSurface(x, y float64) (int, int) {
+	if c.screenXYtransform != nil {
+		x, y = c.screenXYtransform(x, y)
+	}
+	if c.camera != nil {
+		// zoomed and turned blocks cover several pixels, the one in the middle is sure to be theirs
+		x, y = c.camera.WorldToScreen(x+0.5, y+0.5)
+	}
+	return int(math.Floor(x)), int(math.Floor(y))
+}
+
+// GetPoint - colour of block x, y (through the screen transform and camera, like Point) of whatever is being
+// drawn to. Outside it, or when drawing straight to the screen (see SetFrameBuffer), it is Colour{}
+func (c *Context) GetPoint(x, y float64) Colour {
+	w, h, at, ok := c.surface()
+	px, py := c.toSurface(x, y)
+	if !ok || px < 0 || py < 0 || px >= w || py >= h {
+		return Colour{}
+	}
+	return at(px, py)
+}
+
+// closeColour - true if no value of a differs from b by more than tolerance (0-255)
+func closeColour(a, b Colour, tolerance float64) bool {
+	return math.Abs(a.R-b.R) <= tolerance && math.Abs(a.G-b.G) <= tolerance &&
+		math.Abs(a.B-b.B) <= tolerance && math.Abs(a.A-b.A) <= tolerance
+}
+
+// FloodFill - fills the area around block x, y (like Point) that is the same colour as it, give or take tolerance
+// (0-255, how far any of red, green, blue or alpha can be off), with the draw colour. Like a paint program's
+// bucket. Needs something that can be read, see GetPoint
+func (c *Context) FloodFill(x, y, tolerance float64) {
+	w, h, at, ok := c.surface()
+	sx, sy := c.toSurface(x, y)
+	if !ok || sx < 0 || sy < 0 || sx >= w || sy >= h {
+		return
+	}
+	old := at(sx, sy)
+	done := make([]bool, w*h)
+	match := func(x, y int) bool {
+		return !done[y*w+x] && closeColour(at(x, y), old, tolerance)
+	}
+	// scanline fill: fill the run a seed is in, then look for seeds in the rows above and below it
+	type seed struct{ x, y int }
+	type run struct{ x0, x1, y int }
+	stack := []seed{{sx, sy}}
+	var runs []run // filled at the end, so filling doesn't upset matching
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !match(s.x, s.y) {
+			continue
+		}
+		x0, x1 := s.x, s.x
+		for x0 > 0 && match(x0-1, s.y) {
+			x0--
+		}
+		for x1 < w-1 && match(x1+1, s.y) {
+			x1++
+		}
+		for i := x0; i <= x1; i++ {
+			done[s.y*w+i] = true
+		}
+		runs = append(runs, run{x0, x1, s.y})
+		for _, ny := range []int{s.y - 1, s.y + 1} {
+			if ny < 0 || ny >= h {
+				continue
+			}
+			for i := x0; i <= x1; i++ {
+				if match(i, ny) && (i == x0 || !match(i-1, ny)) {
+					stack = append(stack, seed{i, ny})
+				}
+			}
+		}
+	}
+	for _, r := range runs {
+		c.fill(float64(r.x0), float64(r.y), float64(r.x1-r.x0+1), 1)
+	}
+}
+
+// ReplaceColour - changes every block of whatever is being drawn to that is the colour from (give or take
+// tolerance, see FloodFill) to the draw colour, connected or not
+func (c *Context) ReplaceColour(from Colour, tolerance float64) {
+	w, h, at, ok := c.surface()
+	if !ok {
+		return
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; {
+			if !closeColour(at(x, y), from, tolerance) {
+				x++
+				continue
+			}
+			x0 := x
+			for x < w && closeColour(at(x, y), from, tolerance) {
+				x++
+			}
+			c.fill(float64(x0), float64(y), float64(x-x0), 1)
+		}
+	}
+}
+
+// Snapshot - a copy of whatever is being drawn to, a pixel per block, as drawn so far (before post effects).
+// nil when drawing straight to the screen, see SetFrameBuffer
+func (c *Context) Snapshot() image.Image {
+	w, h, at, ok := c.surface()
+	if !ok {
+		return nil
+	}
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := img.PixOffset(x, y)
+			img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = at(x, y).Unpack()
+		}
+	}
+	return img
+}
@@ -0,0 +1,205 @@
+package GameEngine
+
+import "testing"
+
+var (
+	paper = Colour{255, 255, 255, 255}
+	ink   = Colour{200, 0, 0, 255}
+	paint = Colour{0, 0, 200, 255}
+)
+
+// drawnOn - a Context drawing to a w x h target of paper
+func drawnOn(w, h int) (*Context, *RenderTarget) {
+	t := NewRenderTarget(float64(w), float64(h))
+	t.Clear(paper)
+	c := &Context{Blocks: 4, ScrnWidth: float64(w), ScrnHeight: float64(h)}
+	c.SetRenderTarget(t)
+	return c, t
+}
+
+// ring - the edge of the square x0, y0 to x1, y1 in ink, less the blocks in gaps
+func ring(t *RenderTarget, x0, y0, x1, y1 int, gaps ...[2]int) {
+	for y := y0; y <= y1; y++ {
+		for x := x0; x <= x1; x++ {
+			if x == x0 || x == x1 || y == y0 || y == y1 {
+				t.Set(x, y, ink)
+			}
+		}
+	}
+	for _, g := range gaps {
+		t.Set(g[0], g[1], paper)
+	}
+}
+
+// checkFill - every block of t is col where inside says so, ink on the ring round 2, 2 to 8, 8 and paper elsewhere
+func checkFill(tt *testing.T, name string, t *RenderTarget, inside func(x, y int) bool, col Colour) {
+	tt.Helper()
+	for y := 0; y < int(t.H); y++ {
+		for x := 0; x < int(t.W); x++ {
+			want := paper
+			switch {
+			case inside(x, y):
+				want = col
+			case x >= 2 && x <= 8 && y >= 2 && y <= 8 && (x == 2 || x == 8 || y == 2 || y == 8):
+				want = ink
+			}
+			if got := t.Pixel(x, y); got != want {
+				tt.Errorf("%s: %d, %d got %v, want %v", name, x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestFloodFillRing(t *testing.T) {
+	in := func(x, y int) bool { return x > 2 && x < 8 && y > 2 && y < 8 }
+	out := func(x, y int) bool { return !in(x, y) && (x < 2 || x > 8 || y < 2 || y > 8) }
+
+	c, target := drawnOn(12, 12)
+	ring(target, 2, 2, 8, 8)
+	c.SetDrawColor(paint)
+	c.FloodFill(5, 5, 0)
+	checkFill(t, "inside", target, in, paint)
+	c.FloodFill(0, 11, 0)
+	checkFill(t, "outside", target, func(x, y int) bool { return in(x, y) || out(x, y) }, paint)
+
+	// only a corner missing: the fill doesn't get out diagonally
+	c, target = drawnOn(12, 12)
+	ring(target, 2, 2, 8, 8, [2]int{8, 8})
+	c.SetDrawColor(paint)
+	c.FloodFill(3, 3, 0)
+	if got := target.Pixel(8, 8); got != paper {
+		t.Errorf("fill got through a corner: %v", got)
+	}
+	if got := target.Pixel(9, 9); got != paper {
+		t.Errorf("fill got out through a corner: %v", got)
+	}
+	if got := target.Pixel(7, 7); got != paint {
+		t.Errorf("inside not filled: %v", got)
+	}
+
+	// a gap in a side lets it out everywhere, but not onto the ring
+	c, target = drawnOn(12, 12)
+	ring(target, 2, 2, 8, 8, [2]int{8, 5})
+	c.SetDrawColor(paint)
+	c.FloodFill(5, 5, 0)
+	for _, p := range [][2]int{{8, 5}, {0, 0}, {11, 11}, {5, 5}} {
+		if got := target.Pixel(p[0], p[1]); got != paint {
+			t.Errorf("gap: %v got %v", p, got)
+		}
+	}
+	if got := target.Pixel(2, 2); got != ink {
+		t.Errorf("gap: ring filled %v", got)
+	}
+
+	// seeds off the target do nothing
+	c, target = drawnOn(12, 12)
+	c.SetDrawColor(paint)
+	for _, p := range [][2]float64{{-1, 0}, {0, -1}, {12, 0}, {0, 12}} {
+		c.FloodFill(p[0], p[1], 255)
+	}
+	if got := target.Pixel(0, 0); got != paper {
+		t.Errorf("seed off the target filled %v", got)
+	}
+}
+
+func TestFloodFillSameColour(t *testing.T) {
+	for _, tolerance := range []float64{0, 10} {
+		c, target := drawnOn(12, 12)
+		ring(target, 2, 2, 8, 8)
+		// filling with the colour already there has to stop, and changes nothing
+		c.SetDrawColor(paper)
+		c.FloodFill(5, 5, tolerance)
+		c.FloodFill(0, 0, tolerance)
+		c.SetDrawColor(ink)
+		c.FloodFill(2, 2, tolerance)
+		checkFill(t, "same colour", target, func(x, y int) bool { return false }, paint)
+	}
+	// anything is within a tolerance of 255, so that fills the lot, the same colour or not
+	c, target := drawnOn(12, 12)
+	ring(target, 2, 2, 8, 8)
+	c.SetDrawColor(paper)
+	c.FloodFill(5, 5, 255)
+	checkFill(t, "everything", target, func(x, y int) bool { return true }, paper)
+	c.SetDrawColor(paint)
+	c.FloodFill(5, 5, 255)
+	checkFill(t, "everything", target, func(x, y int) bool { return true }, paint)
+}
+
+func TestFloodFillTolerance(t *testing.T) {
+	// red steps up by 10 a block from the seed at the left
+	c, target := drawnOn(8, 3)
+	for x := 0; x < 8; x++ {
+		target.Set(x, 1, Colour{100 + float64(x)*10, 0, 0, 255})
+	}
+	// alpha alone counts too
+	target.Set(1, 0, Colour{100, 0, 0, 234})
+	target.Set(0, 0, Colour{100, 0, 0, 235})
+	c.SetDrawColor(paint)
+	c.FloodFill(0, 1, 20)
+	for x := 0; x < 8; x++ {
+		// tolerance is from the seed's colour, not the block next door, so it doesn't creep along the steps
+		if got, want := target.Pixel(x, 1) == paint, x <= 2; got != want {
+			t.Errorf("%d: filled %v, want %v", x, got, want)
+		}
+	}
+	if target.Pixel(0, 0) != paint || target.Pixel(1, 0) == paint {
+		t.Errorf("alpha edge: %v, %v", target.Pixel(0, 0), target.Pixel(1, 0))
+	}
+	if target.Pixel(0, 2) == paint {
+		t.Errorf("paper filled")
+	}
+}
+
+func TestGetPoint(t *testing.T) {
+	c, target := drawnOn(10, 10)
+	target.Set(4, 4, ink)
+	if got := c.GetPoint(4, 4); got != ink {
+		t.Errorf("got %v", got)
+	}
+	for _, p := range [][2]float64{{-1, 4}, {4, -0.5}, {10, 4}, {4, 10}} {
+		if got := c.GetPoint(p[0], p[1]); got != (Colour{}) {
+			t.Errorf("%v off the target got %v", p, got)
+		}
+	}
+
+	c.screenXYtransform = func(x, y float64) (float64, float64) { return x + 1, 9 - y }
+	if got := c.GetPoint(3, 5); got != ink {
+		t.Errorf("through the transform got %v", got)
+	}
+
+	// looking at 6, 5 moves the world 1 block left; blocks are 2 pixels wide zoomed in
+	c.screenXYtransform = nil
+	cam := NewCamera(0, 0, 10, 10)
+	cam.Pos.X++
+	c.SetCamera(cam)
+	if got := c.GetPoint(5, 4); got != ink {
+		t.Errorf("through the camera got %v", got)
+	}
+	cam.Pos, cam.Zoom = P2D{X: 5, Y: 5}, 2
+	if got := c.GetPoint(4, 4); got != ink {
+		t.Errorf("zoomed got %v", got)
+	}
+	target.Set(4, 4, paper)
+	target.Set(2, 2, ink)
+	if got := c.GetPoint(3, 3); got != ink {
+		t.Errorf("zoomed got %v", got)
+	}
+
+	// both: the transform first, then the camera
+	c.screenXYtransform = func(x, y float64) (float64, float64) { return x - 1, y - 1 }
+	if got := c.GetPoint(4, 4); got != ink {
+		t.Errorf("transform and camera got %v", got)
+	}
+	// and FloodFill seeds go the same way
+	c.SetDrawColor(paint)
+	c.FloodFill(4, 4, 0)
+	if target.Pixel(2, 2) != paint || target.Pixel(0, 0) != paper {
+		t.Errorf("fill through the camera: %v, %v", target.Pixel(2, 2), target.Pixel(0, 0))
+	}
+
+	// nothing to read drawing straight to the screen
+	c = &Context{Blocks: 4, ScrnWidth: 10, ScrnHeight: 10}
+	if got := c.GetPoint(1, 1); got != (Colour{}) {
+		t.Errorf("screen read %v", got)
+	}
+}
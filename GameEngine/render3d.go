@@ -0,0 +1,174 @@
+package GameEngine
+
+import "math"
+
+// Renderer3D - a small software 3D renderer. Projects meshes onto the screen and fills their triangles (blocks)
+// using a ZBuffer so nearer triangles hide further ones
+type Renderer3D struct {
+	Eye     P3D     // camera position
+	Yaw     float64 // camera turn about the Y axis
+	Pitch   float64 // camera tilt about the X axis
+	FOV     float64 // vertical field of view in radians
+	Near    float64 // triangles with a vertex closer than this are skipped
+	Light   V3D     // direction the light shines from
+	Ambient float64 // 0-1 light level of faces turned away from the light
+	Depth   *ZBuffer
+	w, h    float64
+}
+
+// NewRenderer3D - builds a 3D renderer sized to the context screen looking down +Z from the origin
+func NewRenderer3D(c *Context) *Renderer3D {
+	return &Renderer3D{
+		FOV:     PI / 3,
+		Near:    0.1,
+		Light:   V3D{DX: 0.3, DY: 0.5, DZ: -1},
+		Ambient: 0.2,
+		Depth:   NewZBuffer(c.ScrnWidth, c.ScrnHeight),
+		w:       c.ScrnWidth,
+		h:       c.ScrnHeight,
+	}
+}
+
+// Clear - clears the depth buffer ready for the next frame
+func (r *Renderer3D) Clear() {
+	r.Depth.Clear()
+}
+
+// DrawMesh - draws mesh m scaled, rotated (radians about X then Y then Z) and placed at pos. Faces use their
+// material's texture if it has one, otherwise its diffuse colour (white if no material). Faces are Gouraud
+// shaded if the mesh has vertex normals, flat shaded if not
+func (r *Renderer3D) DrawMesh(c *Context, m *Mesh, pos P3D, rot V3D, scale float64) {
+	light := normalise3(r.Light)
+	for _, f := range m.Faces {
+		var world, view [3]V3D
+		for i, fv := range f.Verts {
+			p := m.Positions[fv.V]
+			world[i] = rotate3(V3D{DX: p.X * scale, DY: p.Y * scale, DZ: p.Z * scale}, rot)
+			world[i] = V3D{DX: world[i].DX + pos.X, DY: world[i].DY + pos.Y, DZ: world[i].DZ + pos.Z}
+			view[i] = r.toView(world[i])
+		}
+		if view[0].DZ < r.Near || view[1].DZ < r.Near || view[2].DZ < r.Near {
+			continue
+		}
+		var verts [3]TriangleVertex
+		for i := range view {
+			verts[i].X, verts[i].Y = r.project(view[i])
+			verts[i].Z = view[i].DZ
+		}
+		// back face culling - screen y is down so front faces wind clockwise on screen
+		if (verts[1].X-verts[0].X)*(verts[2].Y-verts[0].Y)-(verts[1].Y-verts[0].Y)*(verts[2].X-verts[0].X) <= 0 {
+			continue
+		}
+
+		col := NewColour(255, 255, 255, 255)
+		var tex *Sprite
+		if mat, ok := m.Materials[f.Material]; ok {
+			col = mat.Kd
+			tex = mat.Texture
+		}
+		if tex != nil {
+			// texture is lit by the vertex colour
+			col = NewColour(255, 255, 255, 255)
+		}
+		faceNormal := normalise3(cross3(sub3(world[1], world[0]), sub3(world[2], world[0])))
+		for i, fv := range f.Verts {
+			n := faceNormal
+			if fv.VN >= 0 {
+				n = normalise3(rotate3(m.Normals[fv.VN], rot))
+			}
+			verts[i].Col = col.Fade(r.Ambient + (1-r.Ambient)*math.Max(0, dot3(n, light)))
+			if fv.VT >= 0 {
+				// obj v runs up the image
+				verts[i].U, verts[i].V = m.TexCoords[fv.VT].X, 1-m.TexCoords[fv.VT].Y
+			}
+		}
+
+		c.rasterTriangleVertex(verts[0], verts[1], verts[2], true, func(x, y float64, frag TriangleVertex) {
+			out := frag.Col
+			if tex != nil {
+				t := tex.SampleSprite(frag.U, frag.V)
+				if t.A == 0 {
+					return
+				}
+				out = Colour{R: t.R * out.R / 255, G: t.G * out.G / 255, B: t.B * out.B / 255, A: t.A}
+			}
+			// depth buffer holds 1/z so that larger is nearer
+			if r.Depth.SetIfNearer(x, y, 1/frag.Z) {
+				c.SetDrawColor(out)
+				c.Point(x, y)
+			}
+		})
+	}
+}
+
+// toView - moves a world point into camera space (camera at origin looking down +Z)
+func (r *Renderer3D) toView(p V3D) V3D {
+	p = sub3(p, V3D{DX: r.Eye.X, DY: r.Eye.Y, DZ: r.Eye.Z})
+	// undo yaw then pitch
+	p = rotate3(p, V3D{DY: -r.Yaw})
+	return rotate3(p, V3D{DX: -r.Pitch})
+}
+
+// project - perspective projects a camera space point to screen blocks
+func (r *Renderer3D) project(p V3D) (x, y float64) {
+	f := (r.h / 2) / math.Tan(r.FOV/2)
+	return r.w/2 + p.DX/p.DZ*f, r.h/2 - p.DY/p.DZ*f
+}
+
+// rasterTriangle - calls plot for each block whose centre is inside the triangle (clipped to w x h) with
+// the barycentric weights of that centre for each vertex
+func rasterTriangle(w, h, x0, y0, x1, y1, x2, y2 float64, plot func(x, y, w0, w1, w2 float64)) {
+	area := (x1-x0)*(y2-y0) - (y1-y0)*(x2-x0)
+	if area == 0 {
+		return
+	}
+	minx := math.Max(0, math.Floor(math.Min(x0, math.Min(x1, x2))))
+	maxx := math.Min(w-1, math.Ceil(math.Max(x0, math.Max(x1, x2))))
+	miny := math.Max(0, math.Floor(math.Min(y0, math.Min(y1, y2))))
+	maxy := math.Min(h-1, math.Ceil(math.Max(y0, math.Max(y1, y2))))
+	for y := miny; y <= maxy; y++ {
+		py := y + 0.5
+		for x := minx; x <= maxx; x++ {
+			px := x + 0.5
+			w0 := ((x2-x1)*(py-y1) - (y2-y1)*(px-x1)) / area
+			w1 := ((x0-x2)*(py-y2) - (y0-y2)*(px-x2)) / area
+			w2 := 1 - w0 - w1
+			if w0 < 0 || w1 < 0 || w2 < 0 {
+				continue
+			}
+			plot(x, y, w0, w1, w2)
+		}
+	}
+}
+
+// small vector helpers for the 3D renderer
+
+func sub3(a, b V3D) V3D {
+	return V3D{DX: a.DX - b.DX, DY: a.DY - b.DY, DZ: a.DZ - b.DZ}
+}
+
+func dot3(a, b V3D) float64 {
+	return a.DX*b.DX + a.DY*b.DY + a.DZ*b.DZ
+}
+
+func cross3(a, b V3D) V3D {
+	return V3D{DX: a.DY*b.DZ - a.DZ*b.DY, DY: a.DZ*b.DX - a.DX*b.DZ, DZ: a.DX*b.DY - a.DY*b.DX}
+}
+
+func normalise3(a V3D) V3D {
+	l := math.Sqrt(dot3(a, a))
+	if l == 0 {
+		return a
+	}
+	return V3D{DX: a.DX / l, DY: a.DY / l, DZ: a.DZ / l}
+}
+
+// rotate3 - rotates a by r.DX about X, then r.DY about Y then r.DZ about Z
+func rotate3(a V3D, r V3D) V3D {
+	s, c := math.Sincos(r.DX)
+	a = V3D{DX: a.DX, DY: a.DY*c - a.DZ*s, DZ: a.DY*s + a.DZ*c}
+	s, c = math.Sincos(r.DY)
+	a = V3D{DX: a.DX*c + a.DZ*s, DY: a.DY, DZ: -a.DX*s + a.DZ*c}
+	s, c = math.Sincos(r.DZ)
+	return V3D{DX: a.DX*c - a.DY*s, DY: a.DX*s + a.DY*c, DZ: a.DZ}
+}
@@ -0,0 +1,234 @@
+package GameEngine
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// BlendMode - how drawn colours mix with what is already there
+type BlendMode int
+
+const (
+	// BlendNone - colours replace what is there
+	BlendNone BlendMode = iota
+	// BlendAlpha - colours are mixed in by their alpha
+	BlendAlpha
+	// BlendAdd - colours (times alpha) are added, lightening
+	BlendAdd
+	// BlendMultiply - what is there is multiplied by the colour, darkening
+	BlendMultiply
+)
+
+// RenderTarget - an offscreen image, one pixel per block, that drawing can be sent to instead of the screen (see
+// SetRenderTarget). It is a Sprite, so it can be drawn back like one
+type RenderTarget struct {
+	Sprite
+	img *image.NRGBA
+}
+
+// NewRenderTarget - clear (transparent) target w x h blocks
+func NewRenderTarget(w, h float64) *RenderTarget {
+	img := image.NewNRGBA(image.Rect(0, 0, int(w), int(h)))
+	return &RenderTarget{Sprite: Sprite{Image: img, W: float64(int(w)), H: float64(int(h))}, img: img}
+}
+
+// Clear - fills the whole target with col. Use a colour with alpha 0 to make it see through
+func (t *RenderTarget) Clear(col Colour) {
+	r, g, b, a := col.Unpack()
+	p := t.img.Pix
+	for i := 0; i < len(p); i += 4 {
+		p[i], p[i+1], p[i+2], p[i+3] = r, g, b, a
+	}
+	t.mask = nil
+}
+
+// Set - sets block x, y to col. Outside the target is ignored
+func (t *RenderTarget) Set(x, y int, col Colour) {
+	if x < 0 || y < 0 || x >= int(t.W) || y >= int(t.H) {
+		return
+	}
+	r, g, b, a := col.Unpack()
+	t.img.SetNRGBA(x, y, color.NRGBA{R: r, G: g, B: b, A: a})
+	t.mask = nil
+}
+
+// Pixel - colour of block x, y. Outside the target the nearest edge block is used
+func (t *RenderTarget) Pixel(x, y int) Colour {
+	x = minInt(maxInt(x, 0), int(t.W)-1)
+	y = minInt(maxInt(y, 0), int(t.H)-1)
+	i := t.img.PixOffset(x, y)
+	p := t.img.Pix
+	return Colour{float64(p[i]), float64(p[i+1]), float64(p[i+2]), float64(p[i+3])}
+}
+
+// blend - mixes col into block x, y
+func (t *RenderTarget) blend(x, y int, col Colour, mode BlendMode) {
+	if mode == BlendNone {
+		t.Set(x, y, col)
+		return
+	}
+	if x < 0 || y < 0 || x >= int(t.W) || y >= int(t.H) {
+		return
+	}
+	t.Set(x, y, blendColour(t.ColourAt(x, y), col, mode))
+}
+
+// blendColour - col drawn over dst in mode, the way SDL does it
+func blendColour(dst, col Colour, mode BlendMode) Colour {
+	a := col.A / 255
+	switch mode {
+	case BlendAlpha:
+		return Colour{
+			R: col.R*a + dst.R*(1-a),
+			G: col.G*a + dst.G*(1-a),
+			B: col.B*a + dst.B*(1-a),
+			A: col.A + dst.A*(1-a),
+		}
+	case BlendAdd:
+		return Colour{
+			R: math.Min(dst.R+col.R*a, 255),
+			G: math.Min(dst.G+col.G*a, 255),
+			B: math.Min(dst.B+col.B*a, 255),
+			A: dst.A,
+		}
+	case BlendMultiply:
+		return Colour{R: dst.R * col.R / 255, G: dst.G * col.G / 255, B: dst.B * col.B / 255, A: dst.A}
+	}
+	return col
+}
+
+// fill - fills the blocks covered by the rectangle x, y, w, h. Anything drawn covers at least one block
+func (t *RenderTarget) fill(x, y, w, h float64, col Colour, mode BlendMode) {
+	x0, y0 := int(math.Floor(x)), int(math.Floor(y))
+	x1, y1 := maxInt(int(math.Floor(x+w)), x0+1), maxInt(int(math.Floor(y+h)), y0+1)
+	x0, y0 = maxInt(x0, 0), maxInt(y0, 0)
+	x1, y1 = minInt(x1, int(t.W)), minInt(y1, int(t.H))
+	for j := y0; j < y1; j++ {
+		for i := x0; i < x1; i++ {
+			t.blend(i, j, col, mode)
+		}
+	}
+}
+
+// SetRenderTarget - sends everything drawn after this to t instead of the screen, with one pixel of t for each
+// block. nil goes back to the frame buffer (or the screen if SetFrameBuffer turned it off)
+func (c *Context) SetRenderTarget(t *RenderTarget) {
+	c.target = t
+}
+
+// RenderTarget - where drawing is going, or nil for the screen
+func (c *Context) RenderTarget() *RenderTarget {
+	return c.target
+}
+
+// SetBlendMode - how everything drawn after this mixes with what is already there
+func (c *Context) SetBlendMode(mode BlendMode) {
+	c.blend = mode
+	switch mode {
+	case BlendAlpha:
+		c.Renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+	case BlendAdd:
+		c.Renderer.SetDrawBlendMode(sdl.BLENDMODE_ADD)
+	case BlendMultiply:
+		c.Renderer.SetDrawBlendMode(sdl.BLENDMODE_MOD)
+	default:
+		c.Renderer.SetDrawBlendMode(sdl.BLENDMODE_NONE)
+	}
+}
+
+// BlendMode - the blend mode set with SetBlendMode
+func (c *Context) BlendMode() BlendMode {
+	return c.blend
+}
+
+// canvas - the render target, or the frame buffer used for post effects, or nil when drawing to the screen. In
+// indexed colour the frame buffer is only written at Present
+func (c *Context) canvas() *RenderTarget {
+	if c.target != nil {
+		return c.target
+	}
+	return c.frame
+}
+
+// scale - pixels in a block of whatever is being drawn to
+func (c *Context) scale() float64 {
+	if c.canvas() != nil {
+		return 1
+	}
+	return c.Blocks
+}
+
+// fill - fills the rectangle x, y, w, h (pixels of whatever is being drawn to) with the draw colour
+func (c *Context) fill(x, y, w, h float64) {
+	if c.target == nil && c.indexed != nil {
+		c.indexed.fill(x, y, w, h, c.index)
+		return
+	}
+	if t := c.canvas(); t != nil {
+		t.fill(x, y, w, h, c.colour, c.blend)
+		return
+	}
+	c.Renderer.FillRect(NewRect(x, y, w, h))
+}
+
+// fineRect - a draw smaller than a block, waiting to go over the frame buffer
+type fineRect struct {
+	x, y, w, h float64 // screen pixels
+	col        Colour
+	blend      BlendMode
+}
+
+// fillFine - fills the rectangle x, y, w, h (screen pixels) with the draw colour over the frame buffer at Present
+func (c *Context) fillFine(x, y, w, h float64) {
+	col := c.colour
+	if c.indexed != nil && int(c.index) < len(c.indexed.pal) {
+		col = c.indexed.pal[c.index]
+	}
+	c.fine = append(c.fine, fineRect{x, y, w, h, col, c.blend})
+}
+
+// drawFine - puts the draws smaller than a block on the screen, in the order they were drawn, and forgets them
+func (c *Context) drawFine() {
+	if len(c.fine) == 0 {
+		return
+	}
+	mode, col := c.blend, c.colour
+	for _, f := range c.fine {
+		c.SetBlendMode(f.blend)
+		c.Renderer.SetDrawColor(f.col.Unpack())
+		c.Renderer.FillRect(NewRect(f.x, f.y, f.w, f.h))
+	}
+	c.SetBlendMode(mode)
+	c.Renderer.SetDrawColor(col.Unpack())
+	c.fine = c.fine[:0]
+}
+
+// DrawSpriteTransformed - draws sprite s centred on x, y, scaled by sx, sy (negative to flip) and turned by angle
+// (radians, clockwise). Transparent pixels are skipped
+func (s *Sprite) DrawSpriteTransformed(c *Context, x, y, sx, sy, angle float64) {
+	if sx == 0 || sy == 0 {
+		return
+	}
+	// corners of the drawn sprite give the blocks to fill, each of which is mapped back into the sprite
+	hw, hh := s.W*math.Abs(sx)/2, s.H*math.Abs(sy)/2
+	ext := V2D{Dx: hw, Dy: hh}.Len()
+	sin, cos := math.Sincos(-angle)
+	for j := math.Floor(y - ext); j <= math.Ceil(y+ext); j++ {
+		for i := math.Floor(x - ext); i <= math.Ceil(x+ext); i++ {
+			dx, dy := i+0.5-x, j+0.5-y
+			u := (dx*cos-dy*sin)/sx + s.W/2
+			v := (dx*sin+dy*cos)/sy + s.H/2
+			if u < 0 || v < 0 || u >= s.W || v >= s.H {
+				continue
+			}
+			col := s.ColourAt(int(u), int(v))
+			if col.A > 0 {
+				c.SetDrawColor(col)
+				c.Point(i, j)
+			}
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package GameEngine
+
+// Scene - one screen of a game: title, play, pause menu, game over. A SceneManager keeps them in a stack and
+// only the top one is updated
+type Scene interface {
+	Enter(sm *SceneManager)                 // put on the stack. Keep sm to change scenes later
+	Exit()                                  // taken off the stack
+	Pause()                                 // another scene was pushed on top
+	Resume()                                // back on top after the one above was popped
+	Update(keys KeyStatus, elapsed float64) // move things on. keys as from PollQuitandKeys
+	Draw(c *Context)                        // draw the whole scene
+}
+
+// Overlay - a scene that implements Overlay and returns true is drawn over the scene under it, e.g. a pause menu
+// over the game. The scene under is drawn but not updated
+type Overlay interface {
+	Overlay() bool
+}
+
+// SceneBase - does nothing for Enter (except keep the manager in SM), Exit, Pause and Resume. Embed it in a scene
+// that only needs Update and Draw
+type SceneBase struct {
+	SM *SceneManager
+}
+
+// Enter - Scene
+func (b *SceneBase) Enter(sm *SceneManager) { b.SM = sm }
+
+// Exit - Scene
+func (b *SceneBase) Exit() {}
+
+// Pause - Scene
+func (b *SceneBase) Pause() {}
+
+// Resume - Scene
+func (b *SceneBase) Resume() {}
+
+// SceneManager - a stack of scenes, changed with Push, Pop and Replace, optionally with an animated Transition
+type SceneManager struct {
+	scenes []Scene
+	quit   bool
+	ctx    *Context // from the last Update, to draw what a transition starts from
+	// the running transition
+	effect   Transition
+	from, to *RenderTarget
+	time     float64
+	duration float64
+}
+
+// NewSceneManager - manager starting with scene first
+func NewSceneManager(first Scene) *SceneManager {
+	sm := &SceneManager{}
+	sm.scenes = append(sm.scenes, first)
+	first.Enter(sm)
+	return sm
+}
+
+// Top - the scene being updated, or nil if the stack is empty
+func (sm *SceneManager) Top() Scene {
+	if len(sm.scenes) == 0 {
+		return nil
+	}
+	return sm.scenes[len(sm.scenes)-1]
+}
+
+// Len - how many scenes are on the stack
+func (sm *SceneManager) Len() int {
+	return len(sm.scenes)
+}
+
+// Quit - makes the next Update return false
+func (sm *SceneManager) Quit() {
+	sm.quit = true
+}
+
+// Push - puts s on top, pausing the scene that was. t (nil for none) runs over duration (Elapsed units)
+func (sm *SceneManager) Push(s Scene, t Transition, duration float64) {
+	sm.change(t, duration, func() {
+		if top := sm.Top(); top != nil {
+			top.Pause()
+		}
+		sm.scenes = append(sm.scenes, s)
+		s.Enter(sm)
+	})
+}
+
+// Pop - takes the top scene off and resumes the one under it. See Push for t and duration
+func (sm *SceneManager) Pop(t Transition, duration float64) {
+	if len(sm.scenes) == 0 {
+		return
+	}
+	sm.change(t, duration, func() {
+		top := sm.Top()
+		sm.scenes = sm.scenes[:len(sm.scenes)-1]
+		top.Exit()
+		if top = sm.Top(); top != nil {
+			top.Resume()
+		}
+	})
+}
+
+// Replace - swaps the top scene for s, e.g. title to game. See Push for t and duration
+func (sm *SceneManager) Replace(s Scene, t Transition, duration float64) {
+	sm.change(t, duration, func() {
+		if top := sm.Top(); top != nil {
+			sm.scenes = sm.scenes[:len(sm.scenes)-1]
+			top.Exit()
+		}
+		sm.scenes = append(sm.scenes, s)
+		s.Enter(sm)
+	})
+}
+
+// change - changes the stack. With a transition the screen as it is now is kept to go from
+func (sm *SceneManager) change(t Transition, duration float64, do func()) {
+	if t == nil || duration <= 0 || sm.ctx == nil {
+		sm.effect = nil
+		do()
+		return
+	}
+	if sm.from == nil {
+		sm.from = NewRenderTarget(sm.ctx.ScrnWidth, sm.ctx.ScrnHeight)
+		sm.to = NewRenderTarget(sm.ctx.ScrnWidth, sm.ctx.ScrnHeight)
+	}
+	sm.drawTo(sm.ctx, sm.from)
+	sm.effect, sm.time, sm.duration = t, 0, duration
+	do()
+}
+
+// Transitioning - true while a transition is running
+func (sm *SceneManager) Transitioning() bool {
+	return sm.effect != nil
+}
+
+// Update - polls the keys, updates the top scene, draws and presents. Returns false when the window is closed or
+// Quit has been called. During a transition the new top scene is updated without keys
+func (sm *SceneManager) Update(c *Context, elapsed float64) (running bool) {
+	running, keys := c.PollQuitandKeys()
+	sm.step(c, keys, elapsed)
+	c.Present()
+	return running && !sm.quit
+}
+
+// step - updates the top scene with keys, moves any transition on and draws
+func (sm *SceneManager) step(c *Context, keys KeyStatus, elapsed float64) {
+	sm.ctx = c
+	if sm.effect != nil {
+		keys = KeyStatus{}
+	}
+	if top := sm.Top(); top != nil {
+		top.Update(keys, elapsed)
+	}
+	if sm.effect != nil {
+		sm.time += elapsed
+		if sm.time >= sm.duration {
+			sm.effect = nil
+		}
+	}
+	if sm.effect == nil {
+		sm.draw(c)
+	} else {
+		sm.drawTo(c, sm.to)
+		sm.blend(c, Clamp01(sm.time/sm.duration))
+	}
+}
+
+// draw - draws the top scene, and the scenes under it if it is an overlay
+func (sm *SceneManager) draw(c *Context) {
+	first := len(sm.scenes) - 1
+	for first > 0 {
+		if o, ok := sm.scenes[first].(Overlay); !ok || !o.Overlay() {
+			break
+		}
+		first--
+	}
+	for i := maxInt(first, 0); i < len(sm.scenes); i++ {
+		sm.scenes[i].Draw(c)
+	}
+}
+
+// drawTo - draws the scenes into t, deferred draws and all
+func (sm *SceneManager) drawTo(c *Context, t *RenderTarget) {
+	prev := c.RenderTarget()
+	c.SetRenderTarget(t)
+	sm.draw(c)
+	c.Flush()
+	c.SetRenderTarget(prev)
+}
+
+// blend - puts the transition t (0-1) of the way through on the screen
+func (sm *SceneManager) blend(c *Context, t float64) {
+	mode := c.BlendMode()
+	c.SetBlendMode(BlendNone)
+	c.ParallelRows(func(s *Surface, y int) {
+		for x := 0; x < s.W; x++ {
+			s.Set(x, y, sm.effect.At(x, y, sm.from, sm.to, t))
+		}
+	})
+	c.SetBlendMode(mode)
+}
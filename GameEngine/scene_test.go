@@ -0,0 +1,264 @@
+package GameEngine
+
+import (
+	"strings"
+	"testing"
+)
+
+// logScene - a scene that notes everything done to it in log and fills the screen with col
+type logScene struct {
+	SceneBase
+	name string
+	log  *[]string
+	over bool
+	col  Colour
+	keys []KeyStatus
+}
+
+func (s *logScene) note(what string) { *s.log = append(*s.log, what+" "+s.name) }
+
+func (s *logScene) Enter(sm *SceneManager) { s.SceneBase.Enter(sm); s.note("enter") }
+func (s *logScene) Exit()                  { s.note("exit") }
+func (s *logScene) Pause()                 { s.note("pause") }
+func (s *logScene) Resume()                { s.note("resume") }
+func (s *logScene) Overlay() bool          { return s.over }
+
+func (s *logScene) Update(keys KeyStatus, elapsed float64) {
+	s.keys = append(s.keys, keys)
+}
+
+func (s *logScene) Draw(c *Context) {
+	s.note("draw")
+	c.SetDrawColor(s.col)
+	c.Clear()
+}
+
+// scenes - logScenes named by names, all noting in one log
+func scenes(names string) (log *[]string, ss []*logScene) {
+	log = &[]string{}
+	for _, n := range strings.Split(names, " ") {
+		ss = append(ss, &logScene{name: n, log: log})
+	}
+	return
+}
+
+func TestSceneLifecycle(t *testing.T) {
+	log, ss := scenes("a b c d")
+	a, b, c, d := ss[0], ss[1], ss[2], ss[3]
+	sm := NewSceneManager(a)
+	if got := strings.Join(*log, ", "); got != "enter a" {
+		t.Errorf("new manager: got %q", got)
+	}
+	for _, tc := range []struct {
+		do   func()
+		want string
+		top  Scene
+		n    int
+	}{
+		{func() { sm.Push(b, nil, 0) }, "pause a, enter b", b, 2},
+		{func() { sm.Push(c, Crossfade{}, 10) }, "pause b, enter c", c, 3}, // no screen yet, so no transition
+		{func() { sm.Pop(nil, 0) }, "exit c, resume b", b, 2},
+		{func() { sm.Replace(d, nil, 0) }, "exit b, enter d", d, 2},
+		{func() { sm.Pop(nil, 0) }, "exit d, resume a", a, 1},
+		{func() { sm.Pop(nil, 0) }, "exit a", nil, 0},
+		{func() { sm.Pop(nil, 0) }, "", nil, 0},
+		{func() { sm.Replace(b, nil, 0) }, "enter b", b, 1},
+	} {
+		*log = nil
+		tc.do()
+		if got := strings.Join(*log, ", "); got != tc.want {
+			t.Errorf("got %q, want %q", got, tc.want)
+		}
+		if sm.Top() != tc.top || sm.Len() != tc.n {
+			t.Errorf("after %q: top %v, %d scenes", tc.want, sm.Top(), sm.Len())
+		}
+		if sm.Transitioning() {
+			t.Errorf("after %q: transitioning", tc.want)
+		}
+	}
+	if b.SM != sm {
+		t.Errorf("scene not given the manager")
+	}
+}
+
+func TestSceneOverlayDraw(t *testing.T) {
+	log, ss := scenes("a b c d")
+	ss[2].over, ss[3].over = true, true
+	sm := NewSceneManager(ss[0])
+	ctx := &Context{Blocks: 1, ScrnWidth: 4, ScrnHeight: 4}
+	ctx.SetRenderTarget(NewRenderTarget(4, 4))
+	for _, tc := range []struct {
+		push *logScene
+		want string
+	}{
+		{nil, "draw a"},
+		{ss[1], "draw b"},
+		// overlays are drawn over the scenes under them, down to the first that isn't one, bottom first
+		{ss[2], "draw b, draw c"},
+		{ss[3], "draw b, draw c, draw d"},
+	} {
+		if tc.push != nil {
+			sm.Push(tc.push, nil, 0)
+		}
+		*log = nil
+		sm.draw(ctx)
+		if got := strings.Join(*log, ", "); got != tc.want {
+			t.Errorf("got %q, want %q", got, tc.want)
+		}
+	}
+	// all overlays down to the bottom
+	log, ss = scenes("a b")
+	ss[0].over, ss[1].over = true, true
+	sm = NewSceneManager(ss[0])
+	sm.Push(ss[1], nil, 0)
+	*log = nil
+	sm.draw(ctx)
+	if got := strings.Join(*log, ", "); got != "draw a, draw b" {
+		t.Errorf("all overlays: got %q", got)
+	}
+}
+
+func TestSceneTransition(t *testing.T) {
+	_, ss := scenes("a b")
+	a, b := ss[0], ss[1]
+	a.col, b.col = Colour{200, 0, 0, 255}, Colour{0, 0, 100, 255}
+	screen := NewRenderTarget(4, 4)
+	ctx := &Context{Blocks: 1, ScrnWidth: 4, ScrnHeight: 4}
+	ctx.SetRenderTarget(screen)
+	sm := NewSceneManager(a)
+	press := KeyStatus{Key: "x", Pressed: true, Event: true}
+	sm.step(ctx, press, 1)
+	if got := screen.Pixel(1, 1); got != a.col {
+		t.Fatalf("first scene drew %v", got)
+	}
+
+	sm.Push(b, Crossfade{}, 10)
+	if !sm.Transitioning() {
+		t.Fatalf("not transitioning")
+	}
+	sm.step(ctx, press, 5)
+	if got := screen.Pixel(2, 3); !within(got, Colour{100, 0, 50, 255}, 1) {
+		t.Errorf("half way got %v", got)
+	}
+	if !sm.Transitioning() {
+		t.Errorf("finished half way")
+	}
+	sm.step(ctx, press, 5)
+	if sm.Transitioning() {
+		t.Errorf("still transitioning at the end")
+	}
+	if got := screen.Pixel(2, 3); got != b.col {
+		t.Errorf("at the end got %v", got)
+	}
+	sm.step(ctx, press, 1)
+	// the new scene is updated throughout, but only gets keys once the transition is over
+	if len(b.keys) != 3 || b.keys[0].Event || b.keys[1].Event || !b.keys[2].Event {
+		t.Errorf("keys %+v", b.keys)
+	}
+	if len(a.keys) != 1 {
+		t.Errorf("paused scene updated")
+	}
+
+	// popping back with a long step ends the transition straight away
+	sm.Pop(Wipe{DirRight}, 10)
+	sm.step(ctx, press, 20)
+	if sm.Transitioning() || screen.Pixel(0, 0) != a.col {
+		t.Errorf("long step: transitioning %v, got %v", sm.Transitioning(), screen.Pixel(0, 0))
+	}
+}
+
+// fromPix, toPix - different colours for every block of the scenes transitions go between
+func fromPix(x, y int) Colour { return Colour{float64(x*20 + 1), float64(y*20 + 1), 0, 255} }
+func toPix(x, y int) Colour   { return Colour{0, float64(y*20 + 1), float64(x*20 + 1), 255} }
+
+// scenePair - from and to targets w x h filled with fromPix and toPix
+func scenePair(w, h int) (from, to *RenderTarget) {
+	from, to = NewRenderTarget(float64(w), float64(h)), NewRenderTarget(float64(w), float64(h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			from.Set(x, y, fromPix(x, y))
+			to.Set(x, y, toPix(x, y))
+		}
+	}
+	return
+}
+
+// checkTransition - every block of tr at t is as want says
+func checkTransition(t *testing.T, name string, tr Transition, w, h int, at float64, want func(x, y int) Colour) {
+	t.Helper()
+	from, to := scenePair(w, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if got, exp := tr.At(x, y, from, to, at), want(x, y); !within(got, exp, 0.01) {
+				t.Errorf("%s at %v: %d, %d got %v, want %v", name, at, x, y, got, exp)
+			}
+		}
+	}
+}
+
+func TestTransitions(t *testing.T) {
+	for _, tr := range []Transition{Crossfade{}, FadeThrough{}, Wipe{DirLeft}, Wipe{DirRight}, Wipe{DirUp}, Wipe{DirDown},
+		Slide{DirLeft}, Slide{DirRight}, Slide{DirUp}, Slide{DirDown}} {
+		checkTransition(t, "start", tr, 8, 8, 0, fromPix)
+		checkTransition(t, "end", tr, 8, 8, 1, toPix)
+	}
+
+	half := func(x, y int) Colour { return fromPix(x, y).Lerp(toPix(x, y), 0.5) }
+	checkTransition(t, "crossfade", Crossfade{}, 8, 8, 0.5, half)
+
+	grey := Colour{50, 50, 50, 255}
+	checkTransition(t, "fade through", FadeThrough{grey}, 8, 8, 0.5, func(x, y int) Colour { return grey })
+	checkTransition(t, "fade through", FadeThrough{grey}, 8, 8, 0.25, func(x, y int) Colour {
+		return fromPix(x, y).Lerp(grey, 0.5)
+	})
+	checkTransition(t, "fade through", FadeThrough{grey}, 8, 8, 0.75, func(x, y int) Colour {
+		return grey.Lerp(toPix(x, y), 0.5)
+	})
+
+	// half way the edge is in the middle, with the new scene on the side it came from
+	wipes := map[Direction]func(x, y int) bool{
+		DirRight: func(x, y int) bool { return x < 4 },
+		DirLeft:  func(x, y int) bool { return x >= 4 },
+		DirDown:  func(x, y int) bool { return y < 4 },
+		DirUp:    func(x, y int) bool { return y >= 4 },
+	}
+	for d, uncovered := range wipes {
+		checkTransition(t, "wipe", Wipe{d}, 8, 8, 0.5, func(x, y int) Colour {
+			if uncovered(x, y) {
+				return toPix(x, y)
+			}
+			return fromPix(x, y)
+		})
+	}
+
+	// half way through time, eased, is 7/8 of the way across: 7 blocks of 8. The old scene's far edge is left
+	slides := map[Direction]func(x, y int) Colour{
+		DirLeft: func(x, y int) Colour {
+			if x == 0 {
+				return fromPix(7, y)
+			}
+			return toPix(x-1, y)
+		},
+		DirRight: func(x, y int) Colour {
+			if x == 7 {
+				return fromPix(0, y)
+			}
+			return toPix(x+1, y)
+		},
+		DirUp: func(x, y int) Colour {
+			if y == 0 {
+				return fromPix(x, 7)
+			}
+			return toPix(x, y-1)
+		},
+		DirDown: func(x, y int) Colour {
+			if y == 7 {
+				return fromPix(x, 0)
+			}
+			return toPix(x, y+1)
+		},
+	}
+	for d, want := range slides {
+		checkTransition(t, "slide", Slide{d}, 8, 8, 0.5, want)
+	}
+}
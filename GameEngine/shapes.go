@@ -0,0 +1,276 @@
+package GameEngine
+
+import (
+	"math"
+	"sort"
+)
+
+// span - fills blocks x0 to x1 (inclusive) of row y. One rectangle when nothing needs to move the blocks about,
+// otherwise a point at a time so the screen transform and camera see every block
+func (c *Context) span(x0, x1, y float64) {
+	if x1 < x0 {
+		return
+	}
+	if c.screenXYtransform == nil && c.camera == nil {
+		p := c.scale()
+		c.fill(x0*p, y*p, (x1-x0+1)*p, p)
+		return
+	}
+	for x := x0; x <= x1; x++ {
+		c.Point(x, y)
+	}
+}
+
+// Rect - outline of the rectangle w x h blocks with its top left at x, y (blocks)
+func (c *Context) Rect(x, y, w, h float64) {
+	x0, y0 := math.Round(x), math.Round(y)
+	x1, y1 := math.Round(x+w)-1, math.Round(y+h)-1
+	if x1 < x0 || y1 < y0 {
+		return
+	}
+	c.span(x0, x1, y0)
+	c.span(x0, x1, y1)
+	for j := y0 + 1; j < y1; j++ {
+		c.Point(x0, j)
+		c.Point(x1, j)
+	}
+}
+
+// FillRect - filled rectangle w x h blocks with its top left at x, y (blocks)
+func (c *Context) FillRect(x, y, w, h float64) {
+	x0, y0 := math.Round(x), math.Round(y)
+	x1, y1 := math.Round(x+w)-1, math.Round(y+h)-1
+	if x1 < x0 || y1 < y0 {
+		return
+	}
+	if c.screenXYtransform == nil && c.camera == nil {
+		p := c.scale()
+		c.fill(x0*p, y0*p, (x1-x0+1)*p, (y1-y0+1)*p)
+		return
+	}
+	for j := y0; j <= y1; j++ {
+		c.span(x0, x1, j)
+	}
+}
+
+// roundRectPoints - outline of a rectangle with corners of radius r, going round clockwise from the top left
+func roundRectPoints(x, y, w, h, r float64) []P2D {
+	// the corners are centred on the blocks r in from each edge
+	x1, y1 := x+w-1, y+h-1
+	r = math.Max(0, math.Min(r, math.Min(x1-x, y1-y)/2))
+	var pts []P2D
+	pts = append(pts, arcPoints(x+r, y+r, r, r, math.Pi, 1.5*math.Pi)...)
+	pts = append(pts, arcPoints(x1-r, y+r, r, r, 1.5*math.Pi, 2*math.Pi)...)
+	pts = append(pts, arcPoints(x1-r, y1-r, r, r, 0, 0.5*math.Pi)...)
+	pts = append(pts, arcPoints(x+r, y1-r, r, r, 0.5*math.Pi, math.Pi)...)
+	return pts
+}
+
+// RoundRect - outline of a rectangle like Rect with corners rounded to radius r (blocks)
+func (c *Context) RoundRect(x, y, w, h, r float64) {
+	c.Polygon(roundRectPoints(math.Round(x), math.Round(y), math.Round(w), math.Round(h), r))
+}
+
+// FillRoundRect - filled rectangle like FillRect with corners rounded to radius r (blocks)
+func (c *Context) FillRoundRect(x, y, w, h, r float64) {
+	c.FillPolygon(roundRectPoints(math.Round(x), math.Round(y), math.Round(w), math.Round(h), r))
+}
+
+// lines - straight lines from point to point
+func (c *Context) lines(pts []P2D) {
+	if len(pts) == 1 {
+		c.Point(pts[0].X, pts[0].Y)
+	}
+	for i := 1; i < len(pts); i++ {
+		c.Line(pts[i-1].X, pts[i-1].Y, pts[i].X, pts[i].Y)
+	}
+}
+
+// Polygon - outline of the polygon with corners pts (blocks), closed back to the first
+func (c *Context) Polygon(pts []P2D) {
+	if len(pts) == 0 {
+		return
+	}
+	c.lines(append(append([]P2D{}, pts...), pts[0]))
+}
+
+// FillPolygon - filled polygon with corners pts (blocks). It can be concave or cross itself, where the even-odd
+// rule decides what is inside: a block is filled if a line from it out of the polygon crosses an odd number of
+// edges, so overlapping loops leave holes. The edges are filled too, so it covers the same blocks as Polygon
+func (c *Context) FillPolygon(pts []P2D) {
+	c.scanPolygon(pts)
+	c.Polygon(pts)
+}
+
+// scanPolygon - fills the blocks inside pts by the even-odd rule, without the edges, so shapes that meet don't grow
+func (c *Context) scanPolygon(pts []P2D) {
+	if len(pts) < 3 {
+		return
+	}
+	top, bottom := pts[0].Y, pts[0].Y
+	for _, p := range pts {
+		top, bottom = math.Min(top, p.Y), math.Max(bottom, p.Y)
+	}
+	var xs []float64
+	for y := math.Ceil(top); y <= bottom; y++ {
+		// where the row crosses each edge. Edges count from their top end but not their bottom, so a corner
+		// where two edges meet isn't counted twice
+		xs = xs[:0]
+		for i, a := range pts {
+			b := pts[(i+1)%len(pts)]
+			if (a.Y <= y) != (b.Y <= y) {
+				xs = append(xs, a.X+(y-a.Y)/(b.Y-a.Y)*(b.X-a.X))
+			}
+		}
+		sort.Float64s(xs)
+		for i := 0; i+1 < len(xs); i += 2 {
+			c.span(math.Ceil(xs[i]-1e-9), math.Floor(xs[i+1]+1e-9), y)
+		}
+	}
+}
+
+// Ellipse - outline of the ellipse centred on x, y with radii rx across and ry down (blocks)
+func (c *Context) Ellipse(x, y, rx, ry float64) {
+	if rx < 0 || ry < 0 {
+		return
+	}
+	x, y = math.Round(x), math.Round(y)
+	if rx == 0 && ry == 0 {
+		c.Point(x, y)
+		return
+	}
+	// step along both axes so the steep and the shallow parts are both solid
+	for dx := 0.0; dx <= rx; dx++ {
+		dy := math.Round(ry * math.Sqrt(1-(dx/rx)*(dx/rx)))
+		c.ellipseQuad(x, y, dx, dy)
+	}
+	for dy := 0.0; dy <= ry; dy++ {
+		dx := math.Round(rx * math.Sqrt(1-(dy/ry)*(dy/ry)))
+		c.ellipseQuad(x, y, dx, dy)
+	}
+}
+
+// ellipseQuad - a point mirrored into each quarter round x, y
+func (c *Context) ellipseQuad(x, y, dx, dy float64) {
+	if math.IsNaN(dx) || math.IsNaN(dy) {
+		return
+	}
+	c.Point(x+dx, y+dy)
+	c.Point(x-dx, y+dy)
+	c.Point(x+dx, y-dy)
+	c.Point(x-dx, y-dy)
+}
+
+// FillEllipse - filled ellipse centred on x, y with radii rx across and ry down (blocks)
+func (c *Context) FillEllipse(x, y, rx, ry float64) {
+	if rx < 0 || ry < 0 {
+		return
+	}
+	x, y = math.Round(x), math.Round(y)
+	for dy := -math.Round(ry); dy <= math.Round(ry); dy++ {
+		half := rx
+		if ry > 0 {
+			half = math.Round(rx * math.Sqrt(math.Max(0, 1-(dy/ry)*(dy/ry))))
+		}
+		c.span(x-half, x+half, y+dy)
+	}
+}
+
+// arcPoints - points along the ellipse centred on x, y from angle start to end (radians, clockwise from the right),
+// close enough together to join with straight lines
+func arcPoints(x, y, rx, ry, start, end float64) []P2D {
+	n := int(math.Ceil(math.Abs(end-start)*math.Max(rx, ry)/2)) + 1
+	pts := make([]P2D, 0, n+1)
+	for i := 0; i <= n; i++ {
+		sin, cos := math.Sincos(start + (end-start)*float64(i)/float64(n))
+		pts = append(pts, P2D{X: x + rx*cos, Y: y + ry*sin})
+	}
+	return pts
+}
+
+// Arc - part of the circle centred on x, y with radius r (blocks), from angle start to end. Angles are radians,
+// clockwise from pointing right
+func (c *Context) Arc(x, y, r, start, end float64) {
+	if r < 0 {
+		return
+	}
+	c.lines(arcPoints(x, y, r, r, start, end))
+}
+
+// Pie - outline of a slice of the circle centred on x, y with radius r (blocks), from angle start to end like Arc
+func (c *Context) Pie(x, y, r, start, end float64) {
+	if r < 0 {
+		return
+	}
+	c.Polygon(append([]P2D{{X: x, Y: y}}, arcPoints(x, y, r, r, start, end)...))
+}
+
+// FillPie - filled slice of the circle centred on x, y with radius r (blocks), from angle start to end like Arc
+func (c *Context) FillPie(x, y, r, start, end float64) {
+	if r < 0 {
+		return
+	}
+	c.FillPolygon(append([]P2D{{X: x, Y: y}}, arcPoints(x, y, r, r, start, end)...))
+}
+
+// curveSteps - how many straight pieces to draw a curve with, going by the length of the lines between its points
+func curveSteps(pts ...P2D) int {
+	l := 0.0
+	for i := 1; i < len(pts); i++ {
+		l += math.Hypot(pts[i].X-pts[i-1].X, pts[i].Y-pts[i-1].Y)
+	}
+	return maxInt(int(math.Ceil(l/2)), 1)
+}
+
+// QuadBezier - curve from x0, y0 to x2, y2, pulled towards x1, y1 (blocks)
+func (c *Context) QuadBezier(x0, y0, x1, y1, x2, y2 float64) {
+	n := curveSteps(P2D{X: x0, Y: y0}, P2D{X: x1, Y: y1}, P2D{X: x2, Y: y2})
+	pts := make([]P2D, 0, n+1)
+	for i := 0; i <= n; i++ {
+		t := float64(i) / float64(n)
+		a, b, d := (1-t)*(1-t), 2*(1-t)*t, t*t
+		pts = append(pts, P2D{X: a*x0 + b*x1 + d*x2, Y: a*y0 + b*y1 + d*y2})
+	}
+	c.lines(pts)
+}
+
+// CubicBezier - curve from x0, y0 to x3, y3, leaving towards x1, y1 and arriving from x2, y2 (blocks)
+func (c *Context) CubicBezier(x0, y0, x1, y1, x2, y2, x3, y3 float64) {
+	n := curveSteps(P2D{X: x0, Y: y0}, P2D{X: x1, Y: y1}, P2D{X: x2, Y: y2}, P2D{X: x3, Y: y3})
+	pts := make([]P2D, 0, n+1)
+	for i := 0; i <= n; i++ {
+		t := float64(i) / float64(n)
+		u := 1 - t
+		a, b, d, e := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+		pts = append(pts, P2D{X: a*x0 + b*x1 + d*x2 + e*x3, Y: a*y0 + b*y1 + d*y2 + e*y3})
+	}
+	c.lines(pts)
+}
+
+// CatmullRom - smooth curve through every point of pts (blocks), from the first to the last
+func (c *Context) CatmullRom(pts []P2D) {
+	if len(pts) < 3 {
+		c.lines(pts)
+		return
+	}
+	var out []P2D
+	for i := 0; i+1 < len(pts); i++ {
+		// the ends use themselves as the point beyond
+		p0, p1, p2, p3 := pts[maxInt(i-1, 0)], pts[i], pts[i+1], pts[minInt(i+2, len(pts)-1)]
+		n := curveSteps(p1, p2)
+		for s := 0; s < n; s++ {
+			t := float64(s) / float64(n)
+			out = append(out, catmullRom(p0, p1, p2, p3, t))
+		}
+	}
+	c.lines(append(out, pts[len(pts)-1]))
+}
+
+// catmullRom - point t (0-1) of the way from p1 to p2 on the curve through p0 to p3
+func catmullRom(p0, p1, p2, p3 P2D, t float64) P2D {
+	t2, t3 := t*t, t*t*t
+	f := func(a, b, c, d float64) float64 {
+		return 0.5 * (2*b + (c-a)*t + (2*a-5*b+4*c-d)*t2 + (3*b-a-3*c+d)*t3)
+	}
+	return P2D{X: f(p0.X, p1.X, p2.X, p3.X), Y: f(p0.Y, p1.Y, p2.Y, p3.Y)}
+}
@@ -0,0 +1,62 @@
+package GameEngine
+
+import "testing"
+
+func TestPointScaleKeepsDetail(t *testing.T) {
+	c := &Context{Blocks: 4, ScrnWidth: 64, ScrnHeight: 48}
+	c.SetFrameBuffer(true)
+	c.colour = Colour{255, 255, 255, 255}
+	// two quarter block points side by side in the first block
+	c.PointScale(0, 0, 4)
+	c.PointScale(2, 0, 4)
+	if len(c.fine) != 2 {
+		t.Fatalf("got %d fine draws", len(c.fine))
+	}
+	for i, x := range []float64{0, 2} {
+		f := c.fine[i]
+		if f.x != x || f.y != 0 || f.w != 1 || f.h != 1 || f.col != c.colour {
+			t.Errorf("%d: got %+v", i, f)
+		}
+	}
+	if got := c.frame.Pixel(0, 0); got.A != 0 {
+		t.Errorf("block lit in the frame buffer: %v", got)
+	}
+	// whole blocks still go in the frame buffer
+	c.Point(2, 0)
+	if len(c.fine) != 2 || c.frame.Pixel(2, 0) != c.colour {
+		t.Errorf("whole block not in the frame buffer")
+	}
+	c.Clear()
+	if len(c.fine) != 0 {
+		t.Errorf("clear left %d fine draws", len(c.fine))
+	}
+}
+
+func TestDrawTextScaled(t *testing.T) {
+	c := &Context{Blocks: 4, ScrnWidth: 64, ScrnHeight: 48}
+	c.SetFrameBuffer(true)
+	c.colour = Colour{255, 255, 255, 255}
+	c.DrawText(0, 0, 4, "I")
+	if len(c.fine) == 0 {
+		t.Fatalf("no text drawn")
+	}
+	// at scale 4 a font pixel is one screen pixel, so every one must land on its own pixel
+	seen := map[[2]float64]bool{}
+	for _, f := range c.fine {
+		if f.w != 1 || f.h != 1 {
+			t.Fatalf("font pixel %v, %v in size", f.w, f.h)
+		}
+		at := [2]float64{f.x, f.y}
+		if seen[at] {
+			t.Fatalf("two font pixels at %v", at)
+		}
+		seen[at] = true
+	}
+	for y := 0; y < int(c.frame.H); y++ {
+		for x := 0; x < int(c.frame.W); x++ {
+			if c.frame.Pixel(x, y).A != 0 {
+				t.Fatalf("block %d, %d lit in the frame buffer", x, y)
+			}
+		}
+	}
+}
@@ -0,0 +1,607 @@
+package GameEngine
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// LoadTiled - loads a map saved by the Tiled editor, as .tmx (XML) or .json/.tmj, going by the file's extension
+func LoadTiled(filename string) (m *Tilemap, err error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json", ".tmj":
+		return LoadTiledJSON(filename)
+	}
+	return LoadTMX(filename)
+}
+
+// LoadTMX - loads a Tiled .tmx map along with its tilesets (embedded or in .tsx files) and their images. Only
+// orthogonal, fixed size maps with one image per tileset can be loaded
+func LoadTMX(filename string) (m *Tilemap, err error) {
+	var tm tmxMap
+	if err = readXML(filename, &tm); err != nil {
+		return
+	}
+	if tm.Orientation != "" && tm.Orientation != "orthogonal" {
+		return nil, fmt.Errorf("%s: %s maps aren't supported", filename, tm.Orientation)
+	}
+	if tm.Infinite != 0 {
+		return nil, fmt.Errorf("%s: infinite maps aren't supported", filename)
+	}
+	m = &Tilemap{W: tm.Width, H: tm.Height, TileW: tm.TileWidth, TileH: tm.TileHeight, Properties: tm.Properties.props()}
+	dir := filepath.Dir(filename)
+	for _, t := range tm.Tilesets {
+		ts, err := t.load(dir)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", filename, err)
+		}
+		m.Tilesets = append(m.Tilesets, ts)
+	}
+	if err = m.addTMXLayers(tm.Layers, 0, 0, true); err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	return
+}
+
+// LoadTiledJSON - loads a map saved by Tiled in its JSON format, along with its tilesets and their images. The
+// same limits as LoadTMX apply
+func LoadTiledJSON(filename string) (m *Tilemap, err error) {
+	var jm jsonMap
+	if err = readJSON(filename, &jm); err != nil {
+		return
+	}
+	if jm.Orientation != "" && jm.Orientation != "orthogonal" {
+		return nil, fmt.Errorf("%s: %s maps aren't supported", filename, jm.Orientation)
+	}
+	if jm.Infinite {
+		return nil, fmt.Errorf("%s: infinite maps aren't supported", filename)
+	}
+	m = &Tilemap{W: jm.Width, H: jm.Height, TileW: jm.TileWidth, TileH: jm.TileHeight, Properties: jsonProps(jm.Properties)}
+	dir := filepath.Dir(filename)
+	for _, t := range jm.Tilesets {
+		ts, err := t.load(dir)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", filename, err)
+		}
+		m.Tilesets = append(m.Tilesets, ts)
+	}
+	if err = m.addJSONLayers(jm.Layers, 0, 0, true); err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	return
+}
+
+// tiledTileset - what both formats say about a tileset
+type tiledTileset struct {
+	name         string
+	tileW, tileH float64
+	spacing      float64
+	margin       float64
+	count        int
+	columns      int
+	image        string // relative to the file the tileset was in
+	tiles        map[int]*TileInfo
+}
+
+// build - loads the tileset's image from dir and makes a Tileset
+func (t *tiledTileset) build(firstGID int, dir string) (ts *Tileset, err error) {
+	if t.image == "" {
+		return nil, fmt.Errorf("tileset %q: tilesets of separate images aren't supported", t.name)
+	}
+	img, err := NewSprite(filepath.Join(dir, filepath.FromSlash(t.image)))
+	if err != nil {
+		return nil, fmt.Errorf("tileset %q: %v", t.name, err)
+	}
+	cols := t.columns
+	if cols <= 0 {
+		cols = int((img.W - 2*t.margin + t.spacing) / (t.tileW + t.spacing))
+	}
+	count := t.count
+	if count <= 0 {
+		rows := int((img.H - 2*t.margin + t.spacing) / (t.tileH + t.spacing))
+		count = cols * rows
+	}
+	if cols <= 0 || count <= 0 {
+		return nil, fmt.Errorf("tileset %q: no tiles in image", t.name)
+	}
+	ts = &Tileset{
+		Name:     t.name,
+		FirstGID: firstGID,
+		Sheet: &SpriteSheet{
+			Sheet:         img,
+			SpritesPerCol: float64(cols),
+			SpritesPerRow: math.Ceil(float64(count) / float64(cols)),
+			SpriteW:       t.tileW,
+			SpriteH:       t.tileH,
+		},
+		Count:   count,
+		Margin:  t.margin,
+		Spacing: t.spacing,
+		Tiles:   t.tiles,
+	}
+	if ts.Tiles == nil {
+		ts.Tiles = map[int]*TileInfo{}
+	}
+	return
+}
+
+// tiledData - decodes the tile ids of a layer of w x h tiles
+func tiledData(encoding, compression, text string, n int) (gids []int, err error) {
+	gids = make([]int, 0, n)
+	switch encoding {
+	case "csv":
+		for _, f := range strings.Split(text, ",") {
+			f = strings.TrimSpace(f)
+			if f == "" {
+				continue
+			}
+			g, err := strconv.ParseUint(f, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("bad tile id %q", f)
+			}
+			gids = append(gids, int(g))
+		}
+	case "base64":
+		b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(text))
+		if err != nil {
+			return nil, err
+		}
+		var r io.Reader = bytes.NewReader(b)
+		switch compression {
+		case "":
+		case "zlib":
+			if r, err = zlib.NewReader(r); err != nil {
+				return nil, err
+			}
+		case "gzip":
+			if r, err = gzip.NewReader(r); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("%s compression isn't supported", compression)
+		}
+		if b, err = io.ReadAll(r); err != nil {
+			return nil, err
+		}
+		for i := 0; i+4 <= len(b); i += 4 {
+			gids = append(gids, int(binary.LittleEndian.Uint32(b[i:])))
+		}
+	default:
+		return nil, fmt.Errorf("%s encoding isn't supported", encoding)
+	}
+	if len(gids) != n {
+		return nil, fmt.Errorf("layer has %d tiles, expected %d", len(gids), n)
+	}
+	return
+}
+
+func readXML(filename string, v interface{}) error {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	if err = xml.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%s: %v", filename, err)
+	}
+	return nil
+}
+
+func readJSON(filename string, v interface{}) error {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%s: %v", filename, err)
+	}
+	return nil
+}
+
+// .tmx ///////////////////////////////////////////////////////////////////////
+
+type tmxMap struct {
+	Orientation string       `xml:"orientation,attr"`
+	Infinite    int          `xml:"infinite,attr"`
+	Width       int          `xml:"width,attr"`
+	Height      int          `xml:"height,attr"`
+	TileWidth   float64      `xml:"tilewidth,attr"`
+	TileHeight  float64      `xml:"tileheight,attr"`
+	Properties  tmxProps     `xml:"properties"`
+	Tilesets    []tmxTileset `xml:"tileset"`
+	Layers      []tmxLayer   `xml:",any"` // layers, object groups and groups, in order
+}
+
+type tmxProps struct {
+	Props []struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:"value,attr"`
+		Text  string `xml:",chardata"` // multi line strings
+	} `xml:"property"`
+}
+
+func (p tmxProps) props() Properties {
+	props := Properties{}
+	for _, v := range p.Props {
+		if v.Value == "" {
+			v.Value = v.Text
+		}
+		props[v.Name] = v.Value
+	}
+	return props
+}
+
+type tmxTileset struct {
+	FirstGID   int      `xml:"firstgid,attr"`
+	Source     string   `xml:"source,attr"`
+	Name       string   `xml:"name,attr"`
+	TileWidth  float64  `xml:"tilewidth,attr"`
+	TileHeight float64  `xml:"tileheight,attr"`
+	Spacing    float64  `xml:"spacing,attr"`
+	Margin     float64  `xml:"margin,attr"`
+	TileCount  int      `xml:"tilecount,attr"`
+	Columns    int      `xml:"columns,attr"`
+	Image      tmxImage `xml:"image"`
+	Tiles      []struct {
+		ID         int      `xml:"id,attr"`
+		Type       string   `xml:"type,attr"`
+		Class      string   `xml:"class,attr"`
+		Properties tmxProps `xml:"properties"`
+		Frames     []struct {
+			TileID   int `xml:"tileid,attr"`
+			Duration int `xml:"duration,attr"`
+		} `xml:"animation>frame"`
+	} `xml:"tile"`
+}
+
+type tmxImage struct {
+	Source string `xml:"source,attr"`
+}
+
+// load - makes the tileset, reading it from its .tsx file first if it has one
+func (t tmxTileset) load(dir string) (*Tileset, error) {
+	if t.Source != "" {
+		first := t.FirstGID
+		src := filepath.Join(dir, filepath.FromSlash(t.Source))
+		if strings.ToLower(filepath.Ext(src)) != ".tsx" {
+			var jt jsonTileset
+			if err := readJSON(src, &jt); err != nil {
+				return nil, err
+			}
+			return jt.tileset().build(first, filepath.Dir(src))
+		}
+		t = tmxTileset{}
+		if err := readXML(src, &t); err != nil {
+			return nil, err
+		}
+		return t.tileset().build(first, filepath.Dir(src))
+	}
+	return t.tileset().build(t.FirstGID, dir)
+}
+
+func (t tmxTileset) tileset() *tiledTileset {
+	ts := &tiledTileset{name: t.Name, tileW: t.TileWidth, tileH: t.TileHeight, spacing: t.Spacing, margin: t.Margin,
+		count: t.TileCount, columns: t.Columns, image: t.Image.Source, tiles: map[int]*TileInfo{}}
+	for _, tile := range t.Tiles {
+		info := &TileInfo{Type: tile.Type, Properties: tile.Properties.props()}
+		if info.Type == "" {
+			info.Type = tile.Class
+		}
+		for _, f := range tile.Frames {
+			info.Animation = append(info.Animation, TileFrame{ID: f.TileID, Duration: float64(f.Duration) / 10})
+		}
+		ts.tiles[tile.ID] = info
+	}
+	return ts
+}
+
+type tmxLayer struct {
+	XMLName    xml.Name
+	Name       string      `xml:"name,attr"`
+	Width      int         `xml:"width,attr"`
+	Height     int         `xml:"height,attr"`
+	Visible    string      `xml:"visible,attr"`
+	OffsetX    float64     `xml:"offsetx,attr"`
+	OffsetY    float64     `xml:"offsety,attr"`
+	Properties tmxProps    `xml:"properties"`
+	Data       tmxData     `xml:"data"`
+	Objects    []tmxObject `xml:"object"`
+	Layers     []tmxLayer  `xml:",any"` // inside groups
+}
+
+type tmxData struct {
+	Encoding    string `xml:"encoding,attr"`
+	Compression string `xml:"compression,attr"`
+	Text        string `xml:",chardata"`
+	Tiles       []struct {
+		GID uint32 `xml:"gid,attr"`
+	} `xml:"tile"`
+	Chunks []struct{} `xml:"chunk"`
+}
+
+type tmxObject struct {
+	ID         int        `xml:"id,attr"`
+	Name       string     `xml:"name,attr"`
+	Type       string     `xml:"type,attr"`
+	Class      string     `xml:"class,attr"`
+	X          float64    `xml:"x,attr"`
+	Y          float64    `xml:"y,attr"`
+	Width      float64    `xml:"width,attr"`
+	Height     float64    `xml:"height,attr"`
+	Rotation   float64    `xml:"rotation,attr"`
+	GID        uint32     `xml:"gid,attr"`
+	Visible    string     `xml:"visible,attr"`
+	Properties tmxProps   `xml:"properties"`
+	Ellipse    *struct{}  `xml:"ellipse"`
+	Point      *struct{}  `xml:"point"`
+	Polygon    *tmxPoints `xml:"polygon"`
+	Polyline   *tmxPoints `xml:"polyline"`
+}
+
+type tmxPoints struct {
+	Points string `xml:"points,attr"`
+}
+
+// parse - "x,y x,y ..." corners
+func (p *tmxPoints) parse() (ps []P2D, err error) {
+	for _, xy := range strings.Fields(p.Points) {
+		s := strings.Split(xy, ",")
+		if len(s) != 2 {
+			return nil, fmt.Errorf("bad point %q", xy)
+		}
+		x, err := strconv.ParseFloat(s[0], 64)
+		if err != nil {
+			return nil, err
+		}
+		y, err := strconv.ParseFloat(s[1], 64)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, P2D{X: x, Y: y})
+	}
+	return
+}
+
+// addTMXLayers - adds layers, flattening groups into them. Offsets and visibility of groups pass down
+func (m *Tilemap) addTMXLayers(layers []tmxLayer, ox, oy float64, visible bool) error {
+	for _, l := range layers {
+		vis := visible && l.Visible != "0"
+		ox, oy := ox+l.OffsetX, oy+l.OffsetY
+		switch l.XMLName.Local {
+		case "layer":
+			if len(l.Data.Chunks) > 0 {
+				return fmt.Errorf("layer %q: infinite maps aren't supported", l.Name)
+			}
+			n := l.Width * l.Height
+			var gids []int
+			if l.Data.Encoding == "" {
+				for _, t := range l.Data.Tiles {
+					gids = append(gids, int(t.GID))
+				}
+				if len(gids) != n {
+					return fmt.Errorf("layer %q: has %d tiles, expected %d", l.Name, len(gids), n)
+				}
+			} else {
+				var err error
+				if gids, err = tiledData(l.Data.Encoding, l.Data.Compression, l.Data.Text, n); err != nil {
+					return fmt.Errorf("layer %q: %v", l.Name, err)
+				}
+			}
+			m.Layers = append(m.Layers, &TileLayer{Name: l.Name, W: l.Width, H: l.Height, Data: gids, Visible: vis,
+				OffsetX: ox, OffsetY: oy, Properties: l.Properties.props()})
+		case "objectgroup":
+			g := &ObjectGroup{Name: l.Name, Visible: vis, Properties: l.Properties.props()}
+			for _, o := range l.Objects {
+				obj := &TileObject{ID: o.ID, Name: o.Name, Type: o.Type, X: o.X + ox, Y: o.Y + oy, W: o.Width,
+					H: o.Height, Rotation: o.Rotation, GID: int(o.GID), Ellipse: o.Ellipse != nil,
+					Point: o.Point != nil, Visible: o.Visible != "0", Properties: o.Properties.props()}
+				if obj.Type == "" {
+					obj.Type = o.Class
+				}
+				var err error
+				switch {
+				case o.Polygon != nil:
+					obj.Points, err = o.Polygon.parse()
+				case o.Polyline != nil:
+					obj.Points, err = o.Polyline.parse()
+					obj.Polyline = true
+				}
+				if err != nil {
+					return fmt.Errorf("object %d: %v", o.ID, err)
+				}
+				g.Objects = append(g.Objects, obj)
+			}
+			m.ObjectGroups = append(m.ObjectGroups, g)
+		case "group":
+			if err := m.addTMXLayers(l.Layers, ox, oy, vis); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// .json ///////////////////////////////////////////////////////////////////////
+
+type jsonMap struct {
+	Orientation string        `json:"orientation"`
+	Infinite    bool          `json:"infinite"`
+	Width       int           `json:"width"`
+	Height      int           `json:"height"`
+	TileWidth   float64       `json:"tilewidth"`
+	TileHeight  float64       `json:"tileheight"`
+	Properties  []jsonProp    `json:"properties"`
+	Tilesets    []jsonTileset `json:"tilesets"`
+	Layers      []jsonLayer   `json:"layers"`
+}
+
+type jsonProp struct {
+	Name  string          `json:"name"`
+	Value json.RawMessage `json:"value"`
+}
+
+// jsonProps - properties as strings. Numbers and bools are kept as written
+func jsonProps(ps []jsonProp) Properties {
+	props := Properties{}
+	for _, p := range ps {
+		var s string
+		if err := json.Unmarshal(p.Value, &s); err != nil {
+			s = string(p.Value)
+		}
+		props[p.Name] = s
+	}
+	return props
+}
+
+type jsonTileset struct {
+	FirstGID   int     `json:"firstgid"`
+	Source     string  `json:"source"`
+	Name       string  `json:"name"`
+	TileWidth  float64 `json:"tilewidth"`
+	TileHeight float64 `json:"tileheight"`
+	Spacing    float64 `json:"spacing"`
+	Margin     float64 `json:"margin"`
+	TileCount  int     `json:"tilecount"`
+	Columns    int     `json:"columns"`
+	Image      string  `json:"image"`
+	Tiles      []struct {
+		ID         int        `json:"id"`
+		Type       string     `json:"type"`
+		Class      string     `json:"class"`
+		Properties []jsonProp `json:"properties"`
+		Animation  []struct {
+			TileID   int `json:"tileid"`
+			Duration int `json:"duration"`
+		} `json:"animation"`
+	} `json:"tiles"`
+}
+
+// load - makes the tileset, reading it from its .tsx or .json file first if it has one
+func (t jsonTileset) load(dir string) (*Tileset, error) {
+	if t.Source != "" {
+		return tmxTileset{FirstGID: t.FirstGID, Source: t.Source}.load(dir)
+	}
+	return t.tileset().build(t.FirstGID, dir)
+}
+
+func (t jsonTileset) tileset() *tiledTileset {
+	ts := &tiledTileset{name: t.Name, tileW: t.TileWidth, tileH: t.TileHeight, spacing: t.Spacing, margin: t.Margin,
+		count: t.TileCount, columns: t.Columns, image: t.Image, tiles: map[int]*TileInfo{}}
+	for _, tile := range t.Tiles {
+		info := &TileInfo{Type: tile.Type, Properties: jsonProps(tile.Properties)}
+		if info.Type == "" {
+			info.Type = tile.Class
+		}
+		for _, f := range tile.Animation {
+			info.Animation = append(info.Animation, TileFrame{ID: f.TileID, Duration: float64(f.Duration) / 10})
+		}
+		ts.tiles[tile.ID] = info
+	}
+	return ts
+}
+
+type jsonLayer struct {
+	Type        string          `json:"type"`
+	Name        string          `json:"name"`
+	Width       int             `json:"width"`
+	Height      int             `json:"height"`
+	Visible     *bool           `json:"visible"`
+	OffsetX     float64         `json:"offsetx"`
+	OffsetY     float64         `json:"offsety"`
+	Properties  []jsonProp      `json:"properties"`
+	Encoding    string          `json:"encoding"`
+	Compression string          `json:"compression"`
+	Data        json.RawMessage `json:"data"`
+	Objects     []jsonObject    `json:"objects"`
+	Layers      []jsonLayer     `json:"layers"`
+}
+
+type jsonObject struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Type       string     `json:"type"`
+	Class      string     `json:"class"`
+	X          float64    `json:"x"`
+	Y          float64    `json:"y"`
+	Width      float64    `json:"width"`
+	Height     float64    `json:"height"`
+	Rotation   float64    `json:"rotation"`
+	GID        uint32     `json:"gid"`
+	Visible    *bool      `json:"visible"`
+	Ellipse    bool       `json:"ellipse"`
+	Point      bool       `json:"point"`
+	Polygon    []P2D      `json:"polygon"`
+	Polyline   []P2D      `json:"polyline"`
+	Properties []jsonProp `json:"properties"`
+}
+
+// shown - Tiled leaves visible out when it is true
+func shown(v *bool) bool {
+	return v == nil || *v
+}
+
+// addJSONLayers - adds layers, flattening groups into them. Offsets and visibility of groups pass down
+func (m *Tilemap) addJSONLayers(layers []jsonLayer, ox, oy float64, visible bool) error {
+	for _, l := range layers {
+		vis := visible && shown(l.Visible)
+		ox, oy := ox+l.OffsetX, oy+l.OffsetY
+		switch l.Type {
+		case "tilelayer":
+			n := l.Width * l.Height
+			var gids []int
+			if l.Encoding == "base64" {
+				var text string
+				if err := json.Unmarshal(l.Data, &text); err != nil {
+					return fmt.Errorf("layer %q: %v", l.Name, err)
+				}
+				var err error
+				if gids, err = tiledData(l.Encoding, l.Compression, text, n); err != nil {
+					return fmt.Errorf("layer %q: %v", l.Name, err)
+				}
+			} else {
+				var raw []uint32
+				if err := json.Unmarshal(l.Data, &raw); err != nil {
+					return fmt.Errorf("layer %q: %v", l.Name, err)
+				}
+				if len(raw) != n {
+					return fmt.Errorf("layer %q: has %d tiles, expected %d", l.Name, len(raw), n)
+				}
+				for _, g := range raw {
+					gids = append(gids, int(g))
+				}
+			}
+			m.Layers = append(m.Layers, &TileLayer{Name: l.Name, W: l.Width, H: l.Height, Data: gids, Visible: vis,
+				OffsetX: ox, OffsetY: oy, Properties: jsonProps(l.Properties)})
+		case "objectgroup":
+			g := &ObjectGroup{Name: l.Name, Visible: vis, Properties: jsonProps(l.Properties)}
+			for _, o := range l.Objects {
+				obj := &TileObject{ID: o.ID, Name: o.Name, Type: o.Type, X: o.X + ox, Y: o.Y + oy, W: o.Width,
+					H: o.Height, Rotation: o.Rotation, GID: int(o.GID), Ellipse: o.Ellipse, Point: o.Point,
+					Visible: shown(o.Visible), Properties: jsonProps(o.Properties), Points: o.Polygon}
+				if obj.Type == "" {
+					obj.Type = o.Class
+				}
+				if o.Polyline != nil {
+					obj.Points, obj.Polyline = o.Polyline, true
+				}
+				g.Objects = append(g.Objects, obj)
+			}
+			m.ObjectGroups = append(m.ObjectGroups, g)
+		case "group":
+			if err := m.addJSONLayers(l.Layers, ox, oy, vis); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,399 @@
+package GameEngine
+
+import (
+	"math"
+	"strconv"
+)
+
+// Tiled keeps flip flags in the top bits of a tile id
+const (
+	TileFlipX    = 0x80000000
+	TileFlipY    = 0x40000000
+	TileFlipDiag = 0x20000000
+	tileIDMask   = 0x1fffffff
+)
+
+// Properties - custom properties from the Tiled editor, all kept as strings
+type Properties map[string]string
+
+// Bool - true if property name is set to true
+func (p Properties) Bool(name string) bool {
+	b, _ := strconv.ParseBool(p[name])
+	return b
+}
+
+// Float - property name as a number, or 0 if it isn't one
+func (p Properties) Float(name string) float64 {
+	f, _ := strconv.ParseFloat(p[name], 64)
+	return f
+}
+
+// TileFrame - one frame of an animated tile. Duration is in Elapsed units
+type TileFrame struct {
+	ID       int // tile within the tileset
+	Duration float64
+}
+
+// TileInfo - extra things known about one tile of a tileset
+type TileInfo struct {
+	Type       string
+	Properties Properties
+	Animation  []TileFrame
+}
+
+// Tileset - a sprite sheet of tiles. Tile ids in layers count on from FirstGID
+type Tileset struct {
+	Name     string
+	FirstGID int
+	Sheet    *SpriteSheet
+	Count    int
+	Margin   float64 // pixels round the edge of the sheet
+	Spacing  float64 // pixels between tiles
+	Tiles    map[int]*TileInfo
+}
+
+// TileLayer - grid of tile ids (0 for no tile)
+type TileLayer struct {
+	Name       string
+	W, H       int
+	Data       []int
+	Visible    bool
+	OffsetX    float64
+	OffsetY    float64
+	Properties Properties
+}
+
+// TileObject - something placed on an object layer. Positions are in pixels (blocks) from the top left of the map.
+// As in Tiled, X, Y is the top left of most objects but the bottom left of tile objects (GID set)
+type TileObject struct {
+	ID         int
+	Name       string
+	Type       string
+	X, Y       float64
+	W, H       float64
+	Rotation   float64 // degrees clockwise
+	GID        int     // tile drawn for the object, 0 if none
+	Points     []P2D   // polygon or polyline corners, relative to X, Y
+	Polyline   bool
+	Ellipse    bool
+	Point      bool
+	Visible    bool
+	Properties Properties
+}
+
+// ObjectGroup - an object layer
+type ObjectGroup struct {
+	Name       string
+	Objects    []*TileObject
+	Visible    bool
+	Properties Properties
+}
+
+// Tilemap - layers of tiles drawn from sprite sheets, as made in the Tiled editor. Tiles are TileW x TileH
+// blocks and the map is W x H tiles
+type Tilemap struct {
+	W, H         int
+	TileW, TileH float64
+	Tilesets     []*Tileset
+	Layers       []*TileLayer
+	ObjectGroups []*ObjectGroup
+	Properties   Properties
+	// SolidFunc - decides if tile id gid of layer blocks movement. If nil a tile is solid if its tileset gives
+	// it the property solid=true, or if it is on a layer with solid=true
+	SolidFunc func(layer *TileLayer, gid int) bool
+	time      float64 // for animations
+}
+
+// NewTilemap - empty w x h map of tiles from sheet, with no layers
+func NewTilemap(sheet *SpriteSheet, w, h int) *Tilemap {
+	return &Tilemap{
+		W:     w,
+		H:     h,
+		TileW: sheet.SpriteW,
+		TileH: sheet.SpriteH,
+		Tilesets: []*Tileset{{
+			FirstGID: 1,
+			Sheet:    sheet,
+			Count:    int(sheet.SpritesPerCol * sheet.SpritesPerRow),
+			Tiles:    map[int]*TileInfo{},
+		}},
+		Properties: Properties{},
+	}
+}
+
+// AddLayer - adds an empty visible layer on top
+func (m *Tilemap) AddLayer(name string) *TileLayer {
+	l := &TileLayer{Name: name, W: m.W, H: m.H, Data: make([]int, m.W*m.H), Visible: true, Properties: Properties{}}
+	m.Layers = append(m.Layers, l)
+	return l
+}
+
+// Layer - the tile layer called name, or nil
+func (m *Tilemap) Layer(name string) *TileLayer {
+	for _, l := range m.Layers {
+		if l.Name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+// ObjectGroup - the object layer called name, or nil
+func (m *Tilemap) ObjectGroup(name string) *ObjectGroup {
+	for _, g := range m.ObjectGroups {
+		if g.Name == name {
+			return g
+		}
+	}
+	return nil
+}
+
+// At - tile id at x, y (in tiles), without flip flags. 0 if empty or outside the layer
+func (l *TileLayer) At(x, y int) int {
+	if x < 0 || y < 0 || x >= l.W || y >= l.H {
+		return 0
+	}
+	return l.Data[y*l.W+x] & tileIDMask
+}
+
+// Set - sets the tile id at x, y (in tiles)
+func (l *TileLayer) Set(x, y, gid int) {
+	if x < 0 || y < 0 || x >= l.W || y >= l.H {
+		return
+	}
+	l.Data[y*l.W+x] = gid
+}
+
+// Tile - tileset holding tile id gid and the tile's number within it. nil if there is no such tile
+func (m *Tilemap) Tile(gid int) (ts *Tileset, id int) {
+	gid &= tileIDMask
+	if gid == 0 {
+		return nil, 0
+	}
+	for _, t := range m.Tilesets {
+		if gid >= t.FirstGID && (ts == nil || t.FirstGID > ts.FirstGID) {
+			ts = t
+		}
+	}
+	if ts == nil {
+		return nil, 0
+	}
+	return ts, gid - ts.FirstGID
+}
+
+// TileInfo - extra information about tile id gid, or nil if there is none
+func (m *Tilemap) TileInfo(gid int) *TileInfo {
+	ts, id := m.Tile(gid)
+	if ts == nil {
+		return nil
+	}
+	return ts.Tiles[id]
+}
+
+// Update - moves animated tiles on
+func (m *Tilemap) Update(elapsed float64) {
+	m.time += elapsed
+}
+
+// Draw - draws the visible layers with the top left of the screen at camX, camY (in blocks) in the map. Only
+// tiles that can be seen are drawn
+func (m *Tilemap) Draw(c *Context, camX, camY float64) {
+	for _, l := range m.Layers {
+		if l.Visible {
+			m.DrawLayer(c, l, camX, camY)
+		}
+	}
+}
+
+// DrawLayer - draws one layer with the top left of the screen at camX, camY
+func (m *Tilemap) DrawLayer(c *Context, l *TileLayer, camX, camY float64) {
+	m.drawTiles(c, l, camX, camY, camX+c.ScrnWidth, camY+c.ScrnHeight, camX, camY)
+}
+
+// DrawView - draws the visible layers in world coordinates through the Context's camera, only drawing the tiles
+// the camera can see. With no camera set the whole map is drawn
+func (m *Tilemap) DrawView(c *Context) {
+	x0, y0 := 0.0, 0.0
+	x1, y1 := float64(m.W)*m.TileW, float64(m.H)*m.TileH
+	if cam := c.Camera(); cam != nil {
+		x0, y0, x1, y1 = cam.Visible()
+	}
+	for _, l := range m.Layers {
+		if l.Visible {
+			m.drawTiles(c, l, x0, y0, x1, y1, 0, 0)
+		}
+	}
+}
+
+// drawTiles - draws the tiles of l that show in the map area x0, y0 to x1, y1, moved by -ox, -oy
+func (m *Tilemap) drawTiles(c *Context, l *TileLayer, x0, y0, x1, y1, ox, oy float64) {
+	x0, x1 = x0-l.OffsetX, x1-l.OffsetX
+	y0, y1 = y0-l.OffsetY, y1-l.OffsetY
+	// tile sets may have bigger tiles than the map, which hang up and right off their cell
+	over := m.overhang()
+	tx0, ty0 := m.ToTile(x0, y0)
+	tx1, ty1 := m.ToTile(x1, y1)
+	for ty := maxInt(ty0, 0); ty <= minInt(ty1+over, l.H-1); ty++ {
+		for tx := maxInt(tx0-over, 0); tx <= minInt(tx1, l.W-1); tx++ {
+			gid := l.Data[ty*l.W+tx]
+			if gid&tileIDMask == 0 {
+				continue
+			}
+			m.DrawTile(c, gid, float64(tx)*m.TileW+l.OffsetX-ox, float64(ty+1)*m.TileH+l.OffsetY-oy)
+		}
+	}
+}
+
+// DrawTile - draws tile id gid (flip flags and all) with its bottom left corner at x, y, as Tiled lines tiles
+// up. Animated tiles show their current frame
+func (m *Tilemap) DrawTile(c *Context, gid int, x, y float64) {
+	ts, id := m.Tile(gid)
+	if ts == nil {
+		return
+	}
+	if info := ts.Tiles[id]; info != nil && len(info.Animation) > 0 {
+		id = frameAt(info.Animation, m.time)
+	}
+	sh := ts.Sheet
+	cols := sh.SpritesPerCol
+	ox := ts.Margin + math.Mod(float64(id), cols)*(sh.SpriteW+ts.Spacing)
+	oy := ts.Margin + math.Floor(float64(id)/cols)*(sh.SpriteH+ts.Spacing)
+	if gid&(TileFlipX|TileFlipY|TileFlipDiag) == 0 {
+		sh.Sheet.DrawPartialSprite(c, x, y-sh.SpriteH, ox, oy, sh.SpriteW, sh.SpriteH)
+		return
+	}
+	// Tiled flips the diagonal, then x, then y, so they are undone the other way round. Flipping the diagonal
+	// turns a tile that isn't square on its side
+	w, h := int(sh.SpriteW), int(sh.SpriteH)
+	if gid&TileFlipDiag != 0 {
+		w, h = h, w
+	}
+	y -= float64(h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			sx, sy := i, j
+			if gid&TileFlipX != 0 {
+				sx = w - 1 - sx
+			}
+			if gid&TileFlipY != 0 {
+				sy = h - 1 - sy
+			}
+			if gid&TileFlipDiag != 0 {
+				sx, sy = sy, sx
+			}
+			col := sh.Sheet.ColourAt(int(ox)+sx, int(oy)+sy)
+			if col.A > 0 {
+				c.SetDrawColor(col)
+				c.Point(x+float64(i), y+float64(j))
+			}
+		}
+	}
+}
+
+// overhang - how many cells the biggest tiles stick out of their cell
+func (m *Tilemap) overhang() (n int) {
+	for _, ts := range m.Tilesets {
+		n = maxInt(n, int(math.Ceil(ts.Sheet.SpriteW/m.TileW))-1)
+		n = maxInt(n, int(math.Ceil(ts.Sheet.SpriteH/m.TileH))-1)
+	}
+	return
+}
+
+// frameAt - tile shown by an animation at time t
+func frameAt(frames []TileFrame, t float64) int {
+	total := 0.0
+	for _, f := range frames {
+		total += f.Duration
+	}
+	if total <= 0 {
+		return frames[0].ID
+	}
+	t = math.Mod(t, total)
+	for _, f := range frames {
+		if t < f.Duration {
+			return f.ID
+		}
+		t -= f.Duration
+	}
+	return frames[len(frames)-1].ID
+}
+
+// ToTile - tile holding the point x, y (in blocks)
+func (m *Tilemap) ToTile(x, y float64) (tx, ty int) {
+	return int(math.Floor(x / m.TileW)), int(math.Floor(y / m.TileH))
+}
+
+// Solid - true if tile x, y (in tiles) is solid on any layer. Outside the map is never solid
+func (m *Tilemap) Solid(x, y int) bool {
+	for _, l := range m.Layers {
+		gid := l.At(x, y)
+		if gid == 0 {
+			continue
+		}
+		if m.SolidFunc != nil {
+			if m.SolidFunc(l, gid) {
+				return true
+			}
+			continue
+		}
+		if l.Properties.Bool("solid") {
+			return true
+		}
+		if info := m.TileInfo(gid); info != nil && info.Properties.Bool("solid") {
+			return true
+		}
+	}
+	return false
+}
+
+// SolidAt - true if the point x, y (in blocks) is in a solid tile
+func (m *Tilemap) SolidAt(x, y float64) bool {
+	return m.Solid(m.ToTile(x, y))
+}
+
+// OverlapsSolid - true if the w x h rectangle with its top left at x, y (in blocks) touches a solid tile
+func (m *Tilemap) OverlapsSolid(x, y, w, h float64) bool {
+	x0, y0 := m.ToTile(x, y)
+	// the far edges are open so a rectangle sitting exactly on a tile isn't in it
+	x1, y1 := m.ToTile(math.Nextafter(x+w, math.Inf(-1)), math.Nextafter(y+h, math.Inf(-1)))
+	for ty := y0; ty <= y1; ty++ {
+		for tx := x0; tx <= x1; tx++ {
+			if m.Solid(tx, ty) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Move - moves the w x h rectangle at x, y by dx, dy, one axis at a time, stopping it flush against any solid
+// tile in the way. Returns where it ended up and which axes were blocked. Moves of more than a tile a step
+// can jump through thin walls
+func (m *Tilemap) Move(x, y, w, h, dx, dy float64) (nx, ny float64, hitX, hitY bool) {
+	nx, ny = x, y
+	if dx != 0 {
+		if m.OverlapsSolid(nx+dx, ny, w, h) {
+			hitX = true
+			if dx > 0 {
+				nx = math.Floor((nx+dx+w)/m.TileW)*m.TileW - w
+			} else {
+				nx = (math.Floor((nx+dx)/m.TileW) + 1) * m.TileW
+			}
+		} else {
+			nx += dx
+		}
+	}
+	if dy != 0 {
+		if m.OverlapsSolid(nx, ny+dy, w, h) {
+			hitY = true
+			if dy > 0 {
+				ny = math.Floor((ny+dy+h)/m.TileH)*m.TileH - h
+			} else {
+				ny = (math.Floor((ny+dy)/m.TileH) + 1) * m.TileH
+			}
+		} else {
+			ny += dy
+		}
+	}
+	return
+}
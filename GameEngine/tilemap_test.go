@@ -0,0 +1,123 @@
+package GameEngine
+
+import "testing"
+
+// source - a 3 x 2 tile with a different colour in every block
+func source(x, y int) Colour {
+	return Colour{float64(x*60 + 10), float64(y*60 + 10), 0, 255}
+}
+
+// flipped - the tile as Tiled shows it with flags: the diagonal flipped first, then x, then y
+func flipped(flags int) (img [][]Colour) {
+	img = make([][]Colour, 2)
+	for y := range img {
+		img[y] = make([]Colour, 3)
+		for x := range img[y] {
+			img[y][x] = source(x, y)
+		}
+	}
+	if flags&TileFlipDiag != 0 {
+		t := make([][]Colour, 3)
+		for y := range t {
+			t[y] = make([]Colour, 2)
+			for x := range t[y] {
+				t[y][x] = img[x][y]
+			}
+		}
+		img = t
+	}
+	if flags&TileFlipX != 0 {
+		for _, row := range img {
+			for l, r := 0, len(row)-1; l < r; l, r = l+1, r-1 {
+				row[l], row[r] = row[r], row[l]
+			}
+		}
+	}
+	if flags&TileFlipY != 0 {
+		for t, b := 0, len(img)-1; t < b; t, b = t+1, b-1 {
+			img[t], img[b] = img[b], img[t]
+		}
+	}
+	return
+}
+
+func TestDrawTileFlips(t *testing.T) {
+	tile := NewRenderTarget(3, 2)
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 3; x++ {
+			tile.Set(x, y, source(x, y))
+		}
+	}
+	m := NewTilemap(&SpriteSheet{Sheet: &tile.Sprite, SpritesPerRow: 1, SpritesPerCol: 1, SpriteW: 3, SpriteH: 2}, 1, 1)
+	c := &Context{Blocks: 1, ScrnWidth: 8, ScrnHeight: 8}
+	for _, flags := range []int{0, TileFlipX, TileFlipY, TileFlipX | TileFlipY, TileFlipDiag, TileFlipDiag | TileFlipX,
+		TileFlipDiag | TileFlipY, TileFlipDiag | TileFlipX | TileFlipY} {
+		rt := NewRenderTarget(8, 8)
+		c.SetRenderTarget(rt)
+		// bottom left corner at 2, 6
+		m.DrawTile(c, 1|flags, 2, 6)
+		want := flipped(flags)
+		h, w := len(want), len(want[0])
+		for y := 0; y < 8; y++ {
+			for x := 0; x < 8; x++ {
+				exp := Colour{}
+				if tx, ty := x-2, y-(6-h); tx >= 0 && tx < w && ty >= 0 && ty < h {
+					exp = want[ty][tx]
+				}
+				if got := rt.Pixel(x, y); got != exp {
+					t.Errorf("flags %x: %d, %d got %v, want %v", flags, x, y, got, exp)
+				}
+			}
+		}
+	}
+	// a quarter turn clockwise puts the bottom left block at the top left
+	rt := NewRenderTarget(8, 8)
+	c.SetRenderTarget(rt)
+	m.DrawTile(c, 1|TileFlipDiag|TileFlipX, 0, 3)
+	if got := rt.Pixel(0, 0); got != source(0, 1) {
+		t.Errorf("quarter turn: got %v", got)
+	}
+}
+
+func TestLoadTiled(t *testing.T) {
+	for _, f := range []string{"../assets/cave.tmx", "../assets/cave.json"} {
+		m, err := LoadTiled(f)
+		if err != nil {
+			t.Fatalf("%s: %v", f, err)
+		}
+		if m.W != 20 || m.H != 8 || m.TileW != 48 || m.TileH != 48 {
+			t.Errorf("%s: map %d x %d of %v x %v", f, m.W, m.H, m.TileW, m.TileH)
+		}
+		if g := m.Properties.Float("gravity"); g != 0.05 {
+			t.Errorf("%s: gravity %v", f, g)
+		}
+		if len(m.Tilesets) != 1 || m.Tilesets[0].Count != 20 || m.Tilesets[0].Sheet.SpriteW != 48 {
+			t.Errorf("%s: tilesets %+v", f, m.Tilesets)
+		}
+		l := m.Layer("ground")
+		if l == nil {
+			t.Fatalf("%s: no ground layer", f)
+		}
+		if l.At(0, 0) != 1 || l.At(1, 1) != 0 || l.At(5, 3) != 12 || l.At(9, 5) != 20 {
+			t.Errorf("%s: tiles %v", f, l.Data[:20])
+		}
+		if !m.Solid(0, 0) || m.Solid(1, 1) || !m.Solid(5, 3) || m.Solid(9, 5) {
+			t.Errorf("%s: solid tiles wrong", f)
+		}
+		info := m.TileInfo(20)
+		if info == nil || len(info.Animation) != 2 || info.Animation[0].ID != 19 || info.Animation[1].ID != 18 ||
+			info.Animation[0].Duration != 50 {
+			t.Errorf("%s: animation %+v", f, info)
+		}
+		g := m.ObjectGroup("things")
+		if g == nil || len(g.Objects) != 5 {
+			t.Fatalf("%s: objects %+v", f, g)
+		}
+		if o := g.Objects[0]; o.Name != "start" || !o.Point || o.X != 72 || o.Y != 250 {
+			t.Errorf("%s: start %+v", f, o)
+		}
+		if o := g.Objects[1]; o.Type != "coin" || o.X != 320 || o.Y != 120 || o.W != 18 || o.H != 18 {
+			t.Errorf("%s: coin %+v", f, o)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package GameEngine
+
+import "math"
+
+// Transition - how one scene turns into the next, see SceneManager
+type Transition interface {
+	// At - colour of block x, y t (0-1) of the way through, going from what was on the screen to the new scene
+	At(x, y int, from, to *RenderTarget, t float64) Colour
+}
+
+// Direction - which way a transition moves
+type Direction int
+
+const (
+	// DirLeft - towards the left of the screen
+	DirLeft Direction = iota
+	// DirRight - towards the right
+	DirRight
+	// DirUp - towards the top
+	DirUp
+	// DirDown - towards the bottom
+	DirDown
+)
+
+// along - how far (0-1) block x, y is along the screen going in direction d
+func (d Direction) along(x, y, w, h int) float64 {
+	switch d {
+	case DirLeft:
+		return 1 - (float64(x)+0.5)/float64(w)
+	case DirUp:
+		return 1 - (float64(y)+0.5)/float64(h)
+	case DirDown:
+		return (float64(y) + 0.5) / float64(h)
+	}
+	return (float64(x) + 0.5) / float64(w)
+}
+
+// Crossfade - the old scene fades into the new one
+type Crossfade struct{}
+
+// At - Transition
+func (Crossfade) At(x, y int, from, to *RenderTarget, t float64) Colour {
+	return from.Pixel(x, y).Lerp(to.Pixel(x, y), t)
+}
+
+// FadeThrough - the old scene fades out to Col, then the new one fades in from it. Black for a classic fade
+type FadeThrough struct {
+	Col Colour
+}
+
+// At - Transition
+func (f FadeThrough) At(x, y int, from, to *RenderTarget, t float64) Colour {
+	if t < 0.5 {
+		return from.Pixel(x, y).Lerp(f.Col, t*2)
+	}
+	return f.Col.Lerp(to.Pixel(x, y), t*2-1)
+}
+
+// Wipe - an edge moves across the screen in Dir, uncovering the new scene behind it
+type Wipe struct {
+	Dir Direction
+}
+
+// At - Transition
+func (wp Wipe) At(x, y int, from, to *RenderTarget, t float64) Colour {
+	if wp.Dir.along(x, y, int(to.W), int(to.H)) < t {
+		return to.Pixel(x, y)
+	}
+	return from.Pixel(x, y)
+}
+
+// Slide - the old scene slides off the screen in Dir and the new one follows it on from the opposite edge, the two
+// moving together like pages on a strip
+type Slide struct {
+	Dir Direction
+}
+
+// At - Transition
+func (sl Slide) At(x, y int, from, to *RenderTarget, t float64) Colour {
+	w, h := int(to.W), int(to.H)
+	// eased so it slows down as it arrives
+	t = 1 - math.Pow(1-t, 3)
+	dx, dy := 0, 0
+	switch sl.Dir {
+	case DirLeft:
+		dx = int(math.Round(t * float64(w)))
+	case DirRight:
+		dx = -int(math.Round(t * float64(w)))
+	case DirUp:
+		dy = int(math.Round(t * float64(h)))
+	case DirDown:
+		dy = -int(math.Round(t * float64(h)))
+	}
+	// where this block would be in the old scene, moved along with it
+	ox, oy := x+dx, y+dy
+	if ox >= 0 && oy >= 0 && ox < w && oy < h {
+		return from.Pixel(ox, oy)
+	}
+	// and in the new one, coming on from the other side
+	nx, ny := ox, oy
+	switch sl.Dir {
+	case DirLeft:
+		nx -= w
+	case DirRight:
+		nx += w
+	case DirUp:
+		ny -= h
+	case DirDown:
+		ny += h
+	}
+	return to.Pixel(nx, ny)
+}
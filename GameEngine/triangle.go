@@ -0,0 +1,88 @@
+package GameEngine
+
+// TriangleVertex - a corner of a filled triangle. Position is in blocks, U and V are normalised texture coords
+// (they wrap so can go past 1 to tile). Z is the distance from the eye and must be > 0 for perspective
+// correct filling
+type TriangleVertex struct {
+	X, Y float64
+	Z    float64
+	U, V float64
+	Col  Colour
+}
+
+// FragmentShader - called for each block inside a triangle with the interpolated vertex values. Returns
+// the colour to draw or false to leave the block alone
+type FragmentShader func(x, y float64, frag TriangleVertex) (Colour, bool)
+
+// TriangleTextured - fills triangle with texture tex using affine (linear in screen space) texture coords.
+// Fine for flat on 2D shapes, warps if the triangle is seen in perspective. Transparent texels are skipped
+func (c *Context) TriangleTextured(v0, v1, v2 TriangleVertex, tex *Sprite) {
+	c.fillTriangle(v0, v1, v2, false, textureShader(tex))
+}
+
+// TriangleTexturedPerspective - fills triangle with texture tex, correcting the texture coords for the
+// vertex depths (Z) so walls and floors don't warp
+func (c *Context) TriangleTexturedPerspective(v0, v1, v2 TriangleVertex, tex *Sprite) {
+	c.fillTriangle(v0, v1, v2, true, textureShader(tex))
+}
+
+// TriangleGouraud - fills triangle blending smoothly between the vertex colours
+func (c *Context) TriangleGouraud(v0, v1, v2 TriangleVertex) {
+	c.fillTriangle(v0, v1, v2, false, func(x, y float64, f TriangleVertex) (Colour, bool) {
+		return f.Col, true
+	})
+}
+
+// TriangleShader - fills triangle calling shader for every block. If all the vertexes have a Z > 0 the
+// values are interpolated perspective correct
+func (c *Context) TriangleShader(v0, v1, v2 TriangleVertex, shader FragmentShader) {
+	c.fillTriangle(v0, v1, v2, v0.Z > 0 && v1.Z > 0 && v2.Z > 0, shader)
+}
+
+// textureShader - shader that samples tex and skips transparent texels
+func textureShader(tex *Sprite) FragmentShader {
+	return func(x, y float64, f TriangleVertex) (Colour, bool) {
+		col := tex.SampleSprite(f.U, f.V)
+		return col, col.A > 0
+	}
+}
+
+// fillTriangle - rasterises the triangle, interpolates the vertex values and draws the colour the shader gives
+func (c *Context) fillTriangle(v0, v1, v2 TriangleVertex, perspective bool, shader FragmentShader) {
+	c.rasterTriangleVertex(v0, v1, v2, perspective, func(x, y float64, f TriangleVertex) {
+		if col, ok := shader(x, y, f); ok {
+			c.SetDrawColor(col)
+			c.Point(x, y)
+		}
+	})
+}
+
+// rasterTriangleVertex - calls plot with the interpolated vertex for every block in the triangle
+func (c *Context) rasterTriangleVertex(v0, v1, v2 TriangleVertex, perspective bool, plot func(x, y float64, f TriangleVertex)) {
+	rasterTriangle(c.ScrnWidth, c.ScrnHeight, v0.X, v0.Y, v1.X, v1.Y, v2.X, v2.Y, func(x, y, w0, w1, w2 float64) {
+		if perspective {
+			// interpolate attribute/z and 1/z then divide back out
+			w0, w1, w2 = w0/v0.Z, w1/v1.Z, w2/v2.Z
+			iz := w0 + w1 + w2
+			w0, w1, w2 = w0/iz, w1/iz, w2/iz
+		}
+		plot(x, y, lerpVertex(v0, v1, v2, w0, w1, w2))
+	})
+}
+
+// lerpVertex - weighted sum of three vertexes
+func lerpVertex(v0, v1, v2 TriangleVertex, w0, w1, w2 float64) TriangleVertex {
+	return TriangleVertex{
+		X: v0.X*w0 + v1.X*w1 + v2.X*w2,
+		Y: v0.Y*w0 + v1.Y*w1 + v2.Y*w2,
+		Z: v0.Z*w0 + v1.Z*w1 + v2.Z*w2,
+		U: v0.U*w0 + v1.U*w1 + v2.U*w2,
+		V: v0.V*w0 + v1.V*w1 + v2.V*w2,
+		Col: Colour{
+			R: v0.Col.R*w0 + v1.Col.R*w1 + v2.Col.R*w2,
+			G: v0.Col.G*w0 + v1.Col.G*w1 + v2.Col.G*w2,
+			B: v0.Col.B*w0 + v1.Col.B*w1 + v2.Col.B*w2,
+			A: v0.Col.A*w0 + v1.Col.A*w1 + v2.Col.A*w2,
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package GameEngine
+
+import "math"
+
+// Add - returns v + w
+func (v V2D) Add(w V2D) V2D {
+	return V2D{Dx: v.Dx + w.Dx, Dy: v.Dy + w.Dy}
+}
+
+// Sub - returns v - w
+func (v V2D) Sub(w V2D) V2D {
+	return V2D{Dx: v.Dx - w.Dx, Dy: v.Dy - w.Dy}
+}
+
+// Scale - returns v multiplied by s
+func (v V2D) Scale(s float64) V2D {
+	return V2D{Dx: v.Dx * s, Dy: v.Dy * s}
+}
+
+// Dot - dot product of v and w
+func (v V2D) Dot(w V2D) float64 {
+	return v.Dx*w.Dx + v.Dy*w.Dy
+}
+
+// Cross - z of the 3D cross product of v and w. Positive if w is clockwise of v on screen (y down)
+func (v V2D) Cross(w V2D) float64 {
+	return v.Dx*w.Dy - v.Dy*w.Dx
+}
+
+// Perp - v turned a quarter turn (clockwise on screen)
+func (v V2D) Perp() V2D {
+	return V2D{Dx: -v.Dy, Dy: v.Dx}
+}
+
+// Len - length of v
+func (v V2D) Len() float64 {
+	return math.Hypot(v.Dx, v.Dy)
+}
+
+// Normalise - v scaled to length 1. Zero vector is returned as is
+func (v V2D) Normalise() V2D {
+	l := v.Len()
+	if l == 0 {
+		return v
+	}
+	return V2D{Dx: v.Dx / l, Dy: v.Dy / l}
+}
+
+// Rotate - v rotated by angle radians
+func (v V2D) Rotate(angle float64) V2D {
+	s, c := math.Sincos(angle)
+	return V2D{Dx: v.Dx*c - v.Dy*s, Dy: v.Dx*s + v.Dy*c}
+}
+
+// Add - point p moved by v
+func (p P2D) Add(v V2D) P2D {
+	return P2D{X: p.X + v.Dx, Y: p.Y + v.Dy}
+}
+
+// Sub - vector from q to p
+func (p P2D) Sub(q P2D) V2D {
+	return V2D{Dx: p.X - q.X, Dy: p.Y - q.Y}
+}
+
+// Dist - distance between p and q
+func (p P2D) Dist(q P2D) float64 {
+	return math.Hypot(p.X-q.X, p.Y-q.Y)
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"container/list"
 	"flag"
 	"fmt"
 	"math"
@@ -9,6 +8,10 @@ import (
 	"os"
 
 	. "github.com/kevincolyer/GameEngine/GameEngine"
+	"github.com/kevincolyer/GameEngine/GameEngine/collision"
+	"github.com/kevincolyer/GameEngine/GameEngine/colours"
+	"github.com/kevincolyer/GameEngine/GameEngine/ecs"
+	"github.com/kevincolyer/GameEngine/GameEngine/physics"
 )
 
 // helper function - can be passed in with GameEngine.New to modify the way blocks are drawn to the screen
@@ -17,33 +20,26 @@ func wrapScreen(x, y float64) (float64, float64) {
 }
 
 var blocksw, blocksh, blocks float64
-var WHITE, BLACK, RED, GREEN, BLUE, GREY50, DARKRED, SADDLEBROWN, STEELBLUE Colour
 var explodeShip bool
+var scenes *SceneManager
 
 var fps = flag.Bool("fps", false, "Display Frames per second")
 var blocksi = flag.Int("blocks", 8, "Blocks of X pixels")
+var aa = flag.Bool("aa", false, "Smooth (anti-aliased) lines")
 
 func main() {
 	flag.Parse()
 	blocksw = 160
 	blocksh = 80
 	blocks = float64(*blocksi)
-	WHITE = NewColour(255, 255, 255, 255)
-	BLACK = NewColour(0, 0, 0, 255)
-	RED = NewColour(255, 0, 0, 255)
-	DARKRED = NewColour(139, 0, 0, 255)
-	GREEN = NewColour(0, 255, 0, 255)
-	BLUE = NewColour(0, 0, 255, 255)
-	GREY50 = NewColour(127, 127, 127, 255)
-	SADDLEBROWN = NewColour(139, 69, 19, 255)
-	STEELBLUE = NewColour(70, 130, 180, 255)
 	var ctx = New(blocks, blocksw, blocksh, "Asteroids", wrapScreen)
 
 	onCreate(ctx)
 	var running = true
 
 	for running {
-		running = onUpdate(ctx, ctx.Elapsed())
+		running = scenes.Update(ctx, ctx.Elapsed())
+		Delay(1)
 	}
 
 	ctx.Destroy()
@@ -67,6 +63,10 @@ func (o Object) Draw(c *Context) {
 		c.Point(o.W[0].X, o.W[0].Y)
 		return
 	}
+	if *aa {
+		c.Polyline(append(append([]P2D{}, o.W...), o.W[0]), LineStyle{AA: true})
+		return
+	}
 	for i := 1; i < l; i++ {
 		c.Line(o.W[i-1].X, o.W[i-1].Y, o.W[i].X, o.W[i].Y)
 	}
@@ -98,21 +98,50 @@ var hiscore int32 = 0
 var worldSpeed float64
 var bulletSpeed float64
 var maxSpeed float64
-var bullets *list.List
-var rocks *list.List
-var explosion [24]*Object
+var explosion *ParticleEmitter
+var fpsElapsed float64 // the last elapsed time, for the fps counter
+
+// rocks and bullets are entities with an Object and a Rock or Bullet tag
+var world *ecs.World
+
+// Rock - tags an entity as a rock
+type Rock struct{}
+
+// Bullet - tags an entity as a bullet
+type Bullet struct{}
+
+// rocks are physics bodies too, so they bounce off each other. There is no gravity in space
+var space *physics.World
+
+// broad phase index of rocks - the screen wraps so the index does too
+var rockIndex *collision.Grid[ecs.Entity]
 
 func onCreate(c *Context) {
 	worldSpeed = 1
 	bulletSpeed = worldSpeed * 0.1
 	maxSpeed = math.Pow(2, 2)
-	// must be before bullets.Init
-	bullets = list.New()
-	rocks = list.New()
+	if *aa {
+		c.SetBlendMode(BlendAlpha)
+	}
+	// must be before resetGame
+	world = ecs.NewWorld()
+	world.AddSystem("rocks", 1, ecs.SystemFunc(moveRocks))
+	world.AddSystem("bullets", 2, ecs.SystemFunc(moveBullets))
+	world.AddSystem("hits", 3, ecs.SystemFunc(bulletHits))
+	world.AddSystem("waves", 4, ecs.SystemFunc(newWave))
+	rockIndex = collision.NewGrid[ecs.Entity](16, collision.World{Bounds: collision.NewAABB(0, 0, blocksw, blocksh), Wrap: true})
+	explosion = NewParticleEmitter(0, 0, 24)
+	explosion.Life = 250
+	explosion.Speed = 0.2
+	explosion.Colours = []Gradient{
+		{{0, colours.White}, {1, colours.Black}},
+		{{0, colours.Red}, {1, colours.Black}},
+	}
+	explosion.WrapW, explosion.WrapH = blocksw, blocksh
 
 	c.Clear()
 	c.Present()
-	resetGame()
+	scenes = NewSceneManager(&titleScene{})
 }
 
 func resetGame() {
@@ -129,10 +158,40 @@ func resetGame() {
 	}
 	score = 0
 	explodeShip = false
-	bullets.Init()
-	rocks.Init()
-	rocks.PushBack(makeRock(blocksw/4, blocksh/2, 16))
-	rocks.PushBack(makeRock(blocksw*3/4, blocksh/2, 16))
+	explosion.Clear()
+	world.Clear()
+	space = physics.NewWorld(V2D{})
+	// rocks drift forever
+	space.SleepTime = 0
+	rockIndex = collision.NewGrid[ecs.Entity](16, rockIndex.World)
+	addRock(blocksw/4, blocksh/2, 16)
+	addRock(blocksw*3/4, blocksh/2, 16)
+}
+
+// addRock - a new rock entity with a body in space shaped like it. It joins queries already running once they
+// finish
+func addRock(x, y float64, size float64) {
+	rock := makeRock(x, y, size)
+	// bodies must be convex, so the rock's hull. Model points are offset from Pos, unturned and unscaled
+	hull := collision.Hull(rock.Model).Points
+	for i := range hull {
+		hull[i] = P2D{X: hull[i].X * size, Y: hull[i].Y * size}
+	}
+	body := physics.NewPolygonBody(x, y, hull, 1)
+	// the body is centred on the hull's centre of mass, move the model to match
+	off := body.Pos.Sub(rock.Pos)
+	for i := range rock.Model {
+		rock.Model[i] = P2D{X: rock.Model[i].X - off.Dx/size, Y: rock.Model[i].Y - off.Dy/size}
+	}
+	rock.Pos = body.Pos
+	body.Vel, body.Angle, body.AngVel = rock.Vel, rock.Angle, rock.Da
+	body.Restitution, body.Friction = 0.9, 0
+	space.Add(body)
+
+	e := world.Create()
+	ecs.Add(world, e, *rock)
+	ecs.Add(world, e, body)
+	ecs.Add(world, e, Rock{})
 }
 
 func makeRock(x, y float64, size float64) (rock *Object) {
@@ -148,66 +207,87 @@ func makeRock(x, y float64, size float64) (rock *Object) {
 	return
 }
 
-func drawExplosion(c *Context, elapsed float64) {
-	if explosion[0].size < 0 {
-		resetGame()
-		return
-	}
-	for _, j := range explosion {
-		col := WHITE
-		if rand.ExpFloat64() > 0.5 {
-			col = RED
-		}
-		col = col.Fade(j.size / 25.0)
-		// update
-		j.Pos.X += j.Vel.Dx * elapsed * worldSpeed * 0.05
-		j.Pos.Y += j.Vel.Dy * elapsed * worldSpeed * 0.05
-		j.size -= elapsed * worldSpeed * .1
-		c.SetDrawColor(col)
-		c.Point(j.Pos.X, j.Pos.Y)
+// nearestImage - the screen wraps so a point has copies a screen apart. Moves points together to the copy
+// nearest to p so they can be tested against something near p
+func nearestImage(points []P2D, p P2D) []P2D {
+	dx := math.Round((p.X-points[0].X)/blocksw) * blocksw
+	dy := math.Round((p.Y-points[0].Y)/blocksh) * blocksh
+	out := make([]P2D, len(points))
+	for i, q := range points {
+		out[i] = P2D{X: q.X + dx, Y: q.Y + dy}
 	}
+	return out
 }
 
 func makeExplosion() {
-	k := 0
-	for i := 0; i < 3; i++ {
-		x0 := ship.W[i].X
-		y0 := ship.W[i].Y
-		x1 := ship.W[(i+1)%3].X
-		y1 := ship.W[(i+1)%3].Y
-		dx := (x0 - x1) / 9
-		dy := (y0 - y1) / 9
-		// split into 8 points
-		for j := 0.0; j < 8; j++ {
-			x := x0 + dx*j
-			y := y0 + dy*j
-			theta := math.Atan2(x-ship.Pos.X, y-ship.Pos.Y) + rand.Float64()*PI/3 - PI/6
-
-			explosion[k] = &Object{
-				size: 25,
-				Pos:  P2D{x, y},
-				Vel: V2D{
-					ship.Vel.Dx + math.Sin(theta)*4,
-					ship.Vel.Dy + math.Cos(theta)*4,
-				},
-			}
-			k++
-		}
-	}
+	explosion.Pos = ship.Pos
+	explosion.Vel = ship.Vel.Scale(0.05)
+	explosion.Radius = ship.size / 2
+	explosion.Burst(24)
 }
 
-func onUpdate(c *Context, elapsed float64) (running bool) {
-	// boilerplate to start
-	// println(elapsed)
-	running, keys := c.PollQuitandKeys()
-	if keys.Event {
-		if keys.Key == "q" {
-			running = false
-		}
+// pressed - true once as key k is let go, so holding it down doesn't keep changing scenes
+func pressed(keys KeyStatus, k string) bool {
+	return keys.Event && keys.Released && keys.Key == k
+}
+
+// centreText - text across the middle of the screen, its top y blocks down. scale as DrawText. Letters are about
+// 8 font pixels wide
+func centreText(c *Context, y, scale float64, text string) {
+	x := (blocksw - float64(len(text)*8)/scale) / 2
+	c.DrawText(x*scale, y*scale, scale, text)
+}
+
+// SCENES ///////////////////////////////////////////
+
+// titleScene - rocks drifting behind the name of the game until space is pressed
+type titleScene struct {
+	SceneBase
+}
+
+func (s *titleScene) Enter(sm *SceneManager) {
+	s.SceneBase.Enter(sm)
+	resetGame()
+}
+
+func (s *titleScene) Update(keys KeyStatus, elapsed float64) {
+	if pressed(keys, "q") {
+		s.SM.Quit()
 	}
-	// Update code here...
-	c.SetDrawColor(BLACK)
+	if pressed(keys, " ") {
+		s.SM.Replace(&playScene{}, FadeThrough{Col: colours.Black}, 100)
+	}
+	moveRocks(world, elapsed)
+}
+
+func (s *titleScene) Draw(c *Context) {
+	c.SetDrawColor(colours.Black)
 	c.Clear()
+	drawRocks(c)
+	c.SetDrawColor(colours.White)
+	centreText(c, blocksh/2-12, 1, "ASTEROIDS")
+	c.SetDrawColor(colours.Grey50)
+	centreText(c, blocksh/2+8, 2, "space to start  q to quit")
+}
+
+// playScene - the game itself
+type playScene struct {
+	SceneBase
+}
+
+func (s *playScene) Enter(sm *SceneManager) {
+	s.SceneBase.Enter(sm)
+	resetGame()
+}
+
+func (s *playScene) Update(keys KeyStatus, elapsed float64) {
+	if pressed(keys, "q") {
+		s.SM.Quit()
+	}
+	if pressed(keys, "p") && explodeShip == false {
+		s.SM.Push(&pauseScene{}, nil, 0)
+		return
+	}
 	// keys //////////////////////////////////////////
 	if keys.Key == "a" {
 		ship.Angle = ship.Angle - 1*elapsed*worldSpeed
@@ -224,7 +304,7 @@ func onUpdate(c *Context, elapsed float64) (running bool) {
 		ship.Vel.Dy = 0
 	}
 	if keys.Key == " " && explodeShip == false {
-		bull := &Object{
+		bull := Object{
 			Pos: P2D{ship.Pos.X, ship.Pos.Y},
 			Vel: V2D{
 				(math.Abs(ship.Vel.Dx) + bulletSpeed) * math.Sin(ship.Angle),
@@ -232,115 +312,196 @@ func onUpdate(c *Context, elapsed float64) (running bool) {
 			},
 			Health: 1000,
 		}
-		bullets.PushFront(bull)
+		e := world.Create()
+		ecs.Add(world, e, bull)
+		ecs.Add(world, e, Bullet{})
 	}
 	// manipulations /////////////////////////////////////
 	// ship
 	ship.Pos.X = Wrap(ship.Pos.X+ship.Vel.Dx*elapsed, 0, blocksw)
 	ship.Pos.Y = Wrap(ship.Pos.Y+ship.Vel.Dy*elapsed, 0, blocksw)
 
-	// bullets(
-	for b := bullets.Front(); b != nil; b = b.Next() {
-		v := b.Value.(*Object)
-		if v.Health > 0 {
-			v.Pos.X += v.Vel.Dx
-			v.Pos.Y += v.Vel.Dy
-			v.Health--
-			if v.Pos.X > blocksw || v.Pos.X < 0 || v.Pos.Y > blocksh || v.Pos.Y < 0 {
-				v.Health = 0
-				bullets.Remove(b)
-			}
-		}
-	}
+	// rocks, bullets and what they hit
+	world.Update(elapsed)
 
-	// rocks
-	for r := rocks.Front(); r != nil; r = r.Next() {
-		rock := r.Value.(*Object)
-		if rock.Health > 0 {
-
-			rock.Angle = rock.Angle + rock.Da*elapsed
-			rock.Pos.X = Wrap(rock.Pos.X+rock.Vel.Dx*elapsed, 0, blocksw)
-			rock.Pos.Y = Wrap(rock.Pos.Y+rock.Vel.Dy*elapsed, 0, blocksh)
-			rock.ScaleRotateTranslate()
-
-			// collision detection
-			// ship
-			rockx := rock.Pos.X
-			rocky := rock.Pos.Y
-			dx := rockx - ship.Pos.X
-			dy := rocky - ship.Pos.Y
-			if dx*dx+dy*dy < (ship.size+rock.size)*(ship.size+rock.size) && explodeShip != true {
+	// collision detection - only rocks the broad phase says are close are tested exactly
+	// ship
+	if explodeShip != true {
+		shipShape := collision.Polygon{Points: ship.W}
+		for _, r := range rockIndex.Query(shipShape.Bounds()) {
+			rock, _ := ecs.Get[Object](world, r)
+			if collision.Overlaps(collision.Polygon{Points: nearestImage(ship.W, rock.Pos)}, collision.Hull(rock.W)) {
 				explodeShip = true
 				makeExplosion()
+				break
 			}
-
-			// bullets
-			for b := bullets.Front(); b != nil; b = b.Next() {
-				v := b.Value.(*Object)
-				if v.Health == 0 {
-					continue
-				}
-				dx = rockx - v.Pos.X
-				dy = rocky - v.Pos.Y
-				if dx*dx+dy*dy < rock.size*rock.size {
-					// hit!
-					// remove bullet, rock and increment score
-					rock.Health = 0
-					score += (16 - int32(rock.size)) * 10
-					v.Health = 0
-					if rock.size > 4 {
-						// make two more rocks
-						rocks.PushFront(makeRock(rock.Pos.X+6, rock.Pos.Y-6, rock.size/2))
-						rocks.PushFront(makeRock(rock.Pos.X-6, rock.Pos.Y+6, rock.size/2))
-					}
-					rocks.Remove(r)
-				}
-			} // end bullets loop
 		}
-	} // end rocks loop
-
-	// add two rocks if all rocks destroyed
-	if rocks.Len() == 0 {
-		score += 1000
-		rocks.PushFront(makeRock(Wrap(ship.Pos.X+blocksw/2, 0, blocksw), rand.Float64()*blocksh, 16))
-		rocks.PushFront(makeRock(Wrap(ship.Pos.X-blocksw/2, 0, blocksw), rand.Float64()*blocksh, 16))
+	} else {
+		explosion.Update(elapsed * worldSpeed)
+		if explosion.Done() {
+			s.SM.Replace(&gameOverScene{}, Wipe{Dir: DirDown}, 80)
+			return
+		}
 	}
 
 	// rotate scale and translate
 	ship.ScaleRotateTranslate()
+	fpsElapsed = elapsed
+}
 
-	// Draw
-	///////////////////////////////////////////////////
-	c.SetDrawColor(SADDLEBROWN)
-	for r := rocks.Front(); r != nil; r = r.Next() {
-		rock := r.Value.(*Object)
-		if rock.Health > 0 {
-			rock.Draw(c)
-		}
-	}
+func (s *playScene) Draw(c *Context) {
+	c.SetDrawColor(colours.Black)
+	c.Clear()
+	drawRocks(c)
 	if explodeShip == false {
-		c.SetDrawColor(WHITE)
+		c.SetDrawColor(colours.White)
 		ship.Draw(c)
 	} else {
-		drawExplosion(c, elapsed)
+		explosion.Draw(c)
 	}
 
-	c.SetDrawColor(STEELBLUE)
-	for b := bullets.Front(); b != nil; b = b.Next() {
-		v := b.Value.(*Object)
-		if v.Health > 0 {
-			c.Point(v.Pos.X, v.Pos.Y)
-		}
-	}
+	c.SetDrawColor(colours.SteelBlue)
+	ecs.Each2(world, func(e ecs.Entity, v *Object, _ *Bullet) {
+		c.Point(v.Pos.X, v.Pos.Y)
+	})
 
 	// Draw text and 'top' layers
-	c.SetDrawColor(DARKRED)
+	c.SetDrawColor(colours.DarkRed)
 	c.DrawText(1, 1, 2, fmt.Sprintf("hi:%v score:%v", hiscore, score))
-	if *fps {
-		c.DrawText(1, 17, 4, fmt.Sprintf("fps:%d", int(100/elapsed)))
+	if *fps && fpsElapsed > 0 {
+		c.DrawText(1, 17, 4, fmt.Sprintf("fps:%d", int(100/fpsElapsed)))
 	}
-	// boilerplate to finish
-	c.Present()
-	Delay(1)
-	return running
+}
+
+// pauseScene - drawn over the game, which stops until p is pressed again
+type pauseScene struct {
+	SceneBase
+}
+
+func (s *pauseScene) Overlay() bool { return true }
+
+func (s *pauseScene) Update(keys KeyStatus, elapsed float64) {
+	if pressed(keys, "q") {
+		s.SM.Quit()
+	}
+	if pressed(keys, "p") {
+		s.SM.Pop(nil, 0)
+	}
+}
+
+func (s *pauseScene) Draw(c *Context) {
+	c.SetDrawColor(colours.White)
+	centreText(c, blocksh/2-4, 1, "PAUSED")
+}
+
+// gameOverScene - the score, until space starts again
+type gameOverScene struct {
+	SceneBase
+}
+
+func (s *gameOverScene) Enter(sm *SceneManager) {
+	s.SceneBase.Enter(sm)
+	if score > hiscore {
+		hiscore = score
+	}
+}
+
+func (s *gameOverScene) Update(keys KeyStatus, elapsed float64) {
+	if pressed(keys, "q") {
+		s.SM.Quit()
+	}
+	if pressed(keys, " ") {
+		s.SM.Replace(&playScene{}, Slide{Dir: DirLeft}, 60)
+	}
+	moveRocks(world, elapsed)
+}
+
+func (s *gameOverScene) Draw(c *Context) {
+	c.SetDrawColor(colours.Black)
+	c.Clear()
+	drawRocks(c)
+	c.SetDrawColor(colours.Red)
+	centreText(c, blocksh/2-16, 1, "GAME OVER")
+	c.SetDrawColor(colours.DarkRed)
+	centreText(c, blocksh/2+2, 2, fmt.Sprintf("score:%v hi:%v", score, hiscore))
+	c.SetDrawColor(colours.Grey50)
+	centreText(c, blocksh/2+10, 2, "space to play  q to quit")
+}
+
+// SYSTEMS //////////////////////////////////////////
+
+// moveRocks - moves the rocks with physics, wrapping them round the screen, and keeps the broad phase index up to
+// date. Rocks only bounce off each other on the same side of the screen edge as physics doesn't wrap
+func moveRocks(w *ecs.World, elapsed float64) {
+	space.Update(elapsed * worldSpeed)
+	ecs.Each2(w, func(r ecs.Entity, rock *Object, body **physics.Body) {
+		b := *body
+		b.Pos = P2D{X: Wrap(b.Pos.X, 0, blocksw), Y: Wrap(b.Pos.Y, 0, blocksh)}
+		rock.Pos, rock.Angle = b.Pos, b.Angle
+		rock.ScaleRotateTranslate()
+		rockIndex.Update(r, collision.Hull(rock.W).Bounds())
+	})
+}
+
+// moveBullets - moves the bullets, removing them when they leave the screen or run out
+func moveBullets(w *ecs.World, elapsed float64) {
+	ecs.Each2(w, func(b ecs.Entity, v *Object, _ *Bullet) {
+		v.Pos.X += v.Vel.Dx
+		v.Pos.Y += v.Vel.Dy
+		v.Health--
+		if v.Health <= 0 || v.Pos.X > blocksw || v.Pos.X < 0 || v.Pos.Y > blocksh || v.Pos.Y < 0 {
+			// safe in the middle of Each2, it happens when it finishes
+			w.Destroy(b)
+		}
+	})
+}
+
+// bulletHits - breaks up rocks hit by bullets
+func bulletHits(w *ecs.World, elapsed float64) {
+	ecs.Each2(w, func(b ecs.Entity, v *Object, _ *Bullet) {
+		if v.Health <= 0 {
+			return
+		}
+		// path the bullet took this frame
+		path := collision.Segment{A: P2D{X: v.Pos.X - v.Vel.Dx, Y: v.Pos.Y - v.Vel.Dy}, B: v.Pos}
+		for _, r := range rockIndex.Query(path.Bounds()) {
+			rock, _ := ecs.Get[Object](w, r)
+			ends := nearestImage([]P2D{path.A, path.B}, rock.Pos)
+			if rock.Health > 0 && collision.Overlaps(collision.Segment{A: ends[0], B: ends[1]}, collision.Hull(rock.W)) {
+				// hit!
+				// remove bullet, rock and increment score
+				rock.Health = 0
+				score += (16 - int32(rock.size)) * 10
+				v.Health = 0
+				if rock.size > 4 {
+					// make two more rocks
+					addRock(rock.Pos.X+6, rock.Pos.Y-6, rock.size/2)
+					addRock(rock.Pos.X-6, rock.Pos.Y+6, rock.size/2)
+				}
+				if body, ok := ecs.Get[*physics.Body](w, r); ok {
+					space.Remove(*body)
+				}
+				w.Destroy(r)
+				w.Destroy(b)
+				rockIndex.Remove(r)
+				break
+			}
+		}
+	})
+}
+
+// newWave - two more rocks when they are all destroyed
+func newWave(w *ecs.World, elapsed float64) {
+	if ecs.Count[Rock](w) == 0 {
+		score += 1000
+		addRock(Wrap(ship.Pos.X+blocksw/2, 0, blocksw), rand.Float64()*blocksh, 16)
+		addRock(Wrap(ship.Pos.X-blocksw/2, 0, blocksw), rand.Float64()*blocksh, 16)
+	}
+}
+
+func drawRocks(c *Context) {
+	c.SetDrawColor(colours.SaddleBrown)
+	ecs.Each2(world, func(r ecs.Entity, rock *Object, _ *Rock) {
+		rock.Draw(c)
+	})
 }
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+
+	. "github.com/kevincolyer/GameEngine/GameEngine"
+	"github.com/kevincolyer/GameEngine/GameEngine/colours"
+)
+
+var blocksw, blocksh, blocks float64
+
+var fps = flag.Bool("fps", false, "Display Frames per second")
+var blocksi = flag.Int("blocks", 2, "Blocks of X pixels")
+var crt = flag.Bool("crt", false, "Old television look")
+var mapfile = flag.String("map", "../../assets/cave.tmx", "Tiled map to play (.tmx or .json)")
+
+var level *Tilemap
+var cam *Camera
+var minimap *RenderTarget
+var flash = &Fade{}
+var flashOn bool         // flash is in the post effects
+var effects []PostEffect // the post effects without the flash
+
+const miniScale = 8.0
+
+var coin *Sprite
+var coins []*TileObject
+var err error
+
+// the player
+var px, py, vx, vy float64
+var onGround bool
+
+const pw, ph = 10.0, 20.0
+
+// draw layers, bottom first
+const (
+	layerMap = iota
+	layerThings
+	layerHUD
+)
+
+var gravity float64
+
+func main() {
+	flag.Parse()
+	blocksw = 320
+	blocksh = 192
+	blocks = float64(*blocksi)
+	var ctx = New(blocks, blocksw, blocksh, "Cave", nil)
+
+	onCreate(ctx)
+	var running = true
+
+	for running {
+		running = onUpdate(ctx, ctx.Elapsed())
+	}
+
+	ctx.Destroy()
+	os.Exit(0)
+}
+
+func onCreate(c *Context) {
+	level, err = LoadTiled(*mapfile)
+	if err != nil {
+		panic(err)
+	}
+	coin, err = NewSprite("../../assets/coin2.png")
+	if err != nil {
+		panic("couldn't load sprite")
+	}
+	gravity = level.Properties.Float("gravity")
+	cam = NewCamera(0, 0, blocksw, blocksh)
+	cam.SetBounds(0, 0, float64(level.W)*level.TileW, float64(level.H)*level.TileH)
+	cam.Deadzone = V2D{Dx: 30, Dy: 20}
+	cam.Smoothing = 10
+	c.SetScreenLayer(layerHUD, true)
+	if *crt {
+		effects = []PostEffect{&Bloom{Threshold: 160, Strength: 0.6, Radius: 2}, &CRT{Scanlines: 0.3, Curvature: 0.05},
+			&Vignette{Radius: 0.6, Strength: 0.7}}
+	}
+	c.SetPostEffects(effects...)
+	if g := level.ObjectGroup("things"); g != nil {
+		for _, o := range g.Objects {
+			switch {
+			case o.Name == "start":
+				px, py = o.X-pw/2, o.Y-ph
+			case o.Type == "coin":
+				coins = append(coins, o)
+			}
+		}
+	}
+	cam.Pos = P2D{X: px, Y: py}
+
+	// draw the whole map small, once, for the minimap
+	mapw, maph := float64(level.W)*level.TileW, float64(level.H)*level.TileH
+	minimap = NewRenderTarget(mapw/miniScale, maph/miniScale)
+	mcam := NewCamera(0, 0, minimap.W, minimap.H)
+	mcam.Zoom = 1 / miniScale
+	mcam.Pos = P2D{X: mapw / 2, Y: maph / 2}
+	c.SetRenderTarget(minimap)
+	c.SetCamera(mcam)
+	level.DrawView(c)
+	c.SetCamera(nil)
+	c.SetRenderTarget(nil)
+	c.Clear()
+	c.Present()
+}
+
+func onUpdate(c *Context, elapsed float64) (running bool) {
+	// boilerplate to start
+	running, keys := c.PollQuitandKeys()
+	if keys.Event {
+		if keys.Key == "q" {
+			running = false
+		}
+		switch keys.Key {
+		case "a":
+			vx = -1.5
+		case "d":
+			vx = 1.5
+		case "w", " ":
+			if onGround {
+				vy = -3.2
+			}
+		case "z":
+			cam.Zoom = math.Min(cam.Zoom*1.25, 4)
+		case "x":
+			cam.Zoom = math.Max(cam.Zoom/1.25, 0.5)
+		}
+		if keys.Released && (keys.Key == "a" || keys.Key == "d") {
+			vx = 0
+		}
+	}
+	c.SetDrawColor(colours.Black)
+	c.Clear()
+
+	// manipulations /////////////////////////////////////
+	level.Update(elapsed)
+	vy = math.Min(vy+gravity*elapsed, 6)
+	var hitY bool
+	px, py, _, hitY = level.Move(px, py, pw, ph, vx*elapsed, vy*elapsed)
+	onGround = hitY && vy > 0
+	if hitY {
+		vy = 0
+	}
+	for i := 0; i < len(coins); i++ {
+		o := coins[i]
+		if px < o.X+o.W && o.X < px+pw && py < o.Y+o.H && o.Y < py+ph {
+			coins = append(coins[:i], coins[i+1:]...)
+			i--
+			cam.Shake(3, 20)
+			flash.Flash(colours.LightYellow, 15)
+		}
+	}
+	// the flash is only an effect while it runs
+	if flash.Flashing() != flashOn {
+		flashOn = !flashOn
+		if flashOn {
+			c.SetPostEffects(append(effects, flash)...)
+		} else {
+			c.SetPostEffects(effects...)
+		}
+	}
+
+	// camera follows the player but stays on the map
+	cam.Follow(px+pw/2, py+ph/2)
+	cam.Update(elapsed)
+
+	// Draw - queued by layer and drawn at Present, so the order here doesn't matter
+	///////////////////////////////////////////////////
+	c.SetCamera(cam)
+	c.Defer(layerMap, 0, level.DrawView)
+	for _, o := range coins {
+		o := o
+		c.Defer(layerThings, o.Y+o.H, func(c *Context) { coin.DrawSprite(c, o.X, o.Y) })
+	}
+	c.Defer(layerThings, py+ph, drawPlayer)
+	c.SetCamera(nil)
+
+	left := len(coins)
+	c.Defer(layerHUD, 0, func(c *Context) {
+		mx := blocksw - minimap.W - 2
+		minimap.DrawSprite(c, mx, 2)
+		c.SetDrawColor(colours.Orange)
+		c.Point(mx+math.Round((px+pw/2)/miniScale), 2+math.Round((py+ph/2)/miniScale))
+		c.SetDrawColor(colours.SteelBlue)
+		c.DrawText(1, 1, 4, fmt.Sprintf("coins left:%d", left))
+		if *fps {
+			c.DrawText(1, 17, 4, fmt.Sprintf("fps:%d", int(100/elapsed)))
+		}
+	})
+	// boilerplate to finish
+	c.Present()
+	Delay(1)
+	return running
+}
+
+// drawPlayer - the player as an orange box
+func drawPlayer(c *Context) {
+	c.SetDrawColor(colours.Orange)
+	for y := 0.0; y < ph; y++ {
+		c.Line(px, py+y, px+pw-1, py+y)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	. "github.com/kevincolyer/GameEngine/GameEngine"
+	"github.com/kevincolyer/GameEngine/GameEngine/colours"
+)
+
+var blocksw, blocksh, blocks float64
+
+var fps = flag.Bool("fps", false, "Display Frames per second")
+var blocksi = flag.Int("blocks", 4, "Blocks of X pixels")
+
+var cube *Mesh
+var r3d *Renderer3D
+var rot V3D
+var err error
+
+func main() {
+	flag.Parse()
+	blocksw = 200
+	blocksh = 150
+	blocks = float64(*blocksi)
+	var ctx = New(blocks, blocksw, blocksh, "Cube", nil)
+
+	onCreate(ctx)
+	var running = true
+
+	for running {
+		running = onUpdate(ctx, ctx.Elapsed())
+	}
+
+	ctx.Destroy()
+	os.Exit(0)
+}
+
+func onCreate(c *Context) {
+	cube, err = LoadOBJ("../../assets/cube.obj")
+	if err != nil {
+		panic(err)
+	}
+	r3d = NewRenderer3D(c)
+	r3d.Eye = P3D{X: 0, Y: 0, Z: -6}
+	c.Clear()
+	c.Present()
+}
+
+func onUpdate(c *Context, elapsed float64) (running bool) {
+	// boilerplate to start
+	running, keys := c.PollQuitandKeys()
+	if keys.Event {
+		if keys.Key == "q" {
+			running = false
+		}
+	}
+	c.SetDrawColor(colours.Black)
+	c.Clear()
+	r3d.Clear()
+
+	// keys //////////////////////////////////////////
+	if keys.Key == "w" {
+		r3d.Eye.Z += 0.1 * elapsed
+	}
+	if keys.Key == "s" {
+		r3d.Eye.Z -= 0.1 * elapsed
+	}
+
+	// manipulations /////////////////////////////////////
+	rot.DX += 0.01 * elapsed
+	rot.DY += 0.015 * elapsed
+
+	// Draw
+	///////////////////////////////////////////////////
+	r3d.DrawMesh(c, cube, P3D{}, rot, 1.5)
+
+	// Draw text and 'top' layers
+	c.SetDrawColor(colours.SteelBlue)
+	if *fps {
+		c.DrawText(1, 17, 4, fmt.Sprintf("fps:%d", int(100/elapsed)))
+	}
+	// boilerplate to finish
+	c.Present()
+	Delay(1)
+	return running
+}
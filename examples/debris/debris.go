@@ -0,0 +1,143 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+
+	. "github.com/kevincolyer/GameEngine/GameEngine"
+	"github.com/kevincolyer/GameEngine/GameEngine/collision"
+	"github.com/kevincolyer/GameEngine/GameEngine/colours"
+	"github.com/kevincolyer/GameEngine/GameEngine/physics"
+)
+
+var blocksw, blocksh, blocks float64
+
+var fps = flag.Bool("fps", false, "Display Frames per second")
+var blocksi = flag.Int("blocks", 4, "Blocks of X pixels")
+
+var world *physics.World
+
+func main() {
+	flag.Parse()
+	blocksw = 160
+	blocksh = 120
+	blocks = float64(*blocksi)
+	var ctx = New(blocks, blocksw, blocksh, "Debris", nil)
+
+	onCreate(ctx)
+	var running = true
+
+	for running {
+		running = onUpdate(ctx, ctx.Elapsed())
+	}
+
+	ctx.Destroy()
+	os.Exit(0)
+}
+
+func onCreate(c *Context) {
+	world = physics.NewWorld(V2D{Dy: 0.01})
+	// floor, walls and a ramp
+	for _, b := range []*physics.Body{
+		physics.NewBoxBody(blocksw/2, blocksh-2, blocksw, 4, 1),
+		physics.NewBoxBody(2, blocksh/2, 4, blocksh, 1),
+		physics.NewBoxBody(blocksw-2, blocksh/2, 4, blocksh, 1),
+		physics.NewPolygonBody(30, 70, []P2D{{X: 0, Y: 0}, {X: 40, Y: 15}, {X: 0, Y: 20}}, 1),
+	} {
+		b.Type = physics.Static
+		world.Add(b)
+	}
+	// a spinning paddle
+	paddle := physics.NewBoxBody(100, 60, 30, 3, 1)
+	paddle.Type = physics.Kinematic
+	paddle.AngVel = 0.01
+	world.Add(paddle)
+	drop()
+	c.Clear()
+	c.Present()
+}
+
+// drop - a handful of random rocks and crates from the top of the screen
+func drop() {
+	for i := 0; i < 10; i++ {
+		x := 20 + rand.Float64()*(blocksw-40)
+		y := 5 + rand.Float64()*20
+		var b *physics.Body
+		switch rand.Intn(3) {
+		case 0:
+			b = physics.NewCircleBody(x, y, 2+rand.Float64()*3, 1)
+		case 1:
+			b = physics.NewBoxBody(x, y, 3+rand.Float64()*6, 3+rand.Float64()*6, 1)
+		default:
+			ps := make([]P2D, 8)
+			for j := range ps {
+				ps[j] = P2D{X: rand.Float64()*8 - 4, Y: rand.Float64()*8 - 4}
+			}
+			b = physics.NewPolygonBody(x, y, collision.Hull(ps).Points, 1)
+		}
+		b.Restitution = 0.3
+		b.Angle = rand.Float64() * 2 * PI
+		world.Add(b)
+	}
+}
+
+func onUpdate(c *Context, elapsed float64) (running bool) {
+	// boilerplate to start
+	running, keys := c.PollQuitandKeys()
+	if keys.Event {
+		if keys.Key == "q" {
+			running = false
+		}
+		if keys.Key == " " && keys.Released {
+			drop()
+		}
+	}
+	c.SetDrawColor(colours.Black)
+	c.Clear()
+
+	// manipulations /////////////////////////////////////
+	world.Update(elapsed)
+	for _, b := range world.Bodies() {
+		if b.Pos.Y > blocksh*2 {
+			world.Remove(b)
+			break
+		}
+	}
+
+	// Draw
+	///////////////////////////////////////////////////
+	for _, b := range world.Bodies() {
+		switch {
+		case b.Type != physics.Dynamic:
+			c.SetDrawColor(colours.Grey50)
+		case b.Sleeping:
+			c.SetDrawColor(colours.SteelBlue)
+		default:
+			c.SetDrawColor(colours.Orange)
+		}
+		switch s := b.Shape().(type) {
+		case collision.Circle:
+			c.DrawCircle(s.C.X, s.C.Y, s.R)
+			d := V2D{Dx: s.R}.Rotate(b.Angle)
+			c.Line(s.C.X, s.C.Y, s.C.X+d.Dx, s.C.Y+d.Dy)
+		case collision.Polygon:
+			ps := s.Points
+			for i := range ps {
+				j := (i + 1) % len(ps)
+				c.Line(ps[i].X, ps[i].Y, ps[j].X, ps[j].Y)
+			}
+		}
+	}
+
+	// Draw text and 'top' layers
+	c.SetDrawColor(colours.SteelBlue)
+	if *fps {
+		c.DrawText(1, 17, 4, fmt.Sprintf("fps:%d", int(100/elapsed)))
+	}
+	// boilerplate to finish
+	c.Present()
+	Delay(1)
+	return running
+}
@@ -81,11 +81,23 @@ func onUpdate(c *Context, elapsed float64) (running bool) {
 	c.SetDrawColor(Colour{R256(), R256(), R256(), 255})
 	c.Triangle(RandIntN(blocksw), RandIntN(blocksh), RandIntN(blocksw), RandIntN(blocksh), RandIntN(blocksw), RandIntN(blocksh))
 
-	R := NewRect(x, y, w, h)
 	c.SetDrawColor(Colour{255, 127, 127, 255})
-	c.Renderer.FillRect(R)
+	c.FillRect(x/blocks, y/blocks, w/blocks, h/blocks)
 	c.SetDrawColor(Colour{0, 0, 0, 255})
-	c.Renderer.DrawRect(R)
+	c.Rect(x/blocks, y/blocks, w/blocks, h/blocks)
+
+	c.SetDrawColor(Colour{127, 127, 255, 255})
+	c.FillRoundRect(120, 50, 30, 20, 5)
+	c.SetDrawColor(Colour{255, 255, 255, 255})
+	c.RoundRect(120, 50, 30, 20, 5)
+	c.SetDrawColor(Colour{255, 255, 0, 255})
+	c.FillPie(135, 20, 12, tick/20, tick/20+5)
+	c.SetDrawColor(Colour{0, 255, 127, 255})
+	c.FillPolygon([]P2D{{X: 100, Y: 10}, {X: 106, Y: 28}, {X: 90, Y: 17}, {X: 110, Y: 17}, {X: 94, Y: 28}})
+	c.Ellipse(80, 65, 15, 8)
+	c.SetDrawColor(Colour{255, 127, 0, 255})
+	c.CubicBezier(10, 70, 30, 40, 50, 100, 70, 60)
+	c.CatmullRom([]P2D{{X: 10, Y: 50}, {X: 25, Y: 40}, {X: 40, Y: 55}, {X: 55, Y: 45}})
 
 	c.SetDrawColor(Colour{R: 255, G: 0, B: 0, A: 255})
 	c.DrawText(1, 1, 2, "Hello Mum!")
@@ -7,15 +7,16 @@ import (
 	"os"
 
 	. "github.com/kevincolyer/GameEngine/GameEngine"
+	"github.com/kevincolyer/GameEngine/GameEngine/colours"
+	"github.com/kevincolyer/GameEngine/GameEngine/raycast"
 )
 
 var blocksw, blocksh, blocks float64
-var WHITE, BLACK, RED, GREEN, BLUE, GREY50, DARKRED, SADDLEBROWN, STEELBLUE Colour
 
 var fps = flag.Bool("fps", false, "Display Frames per second")
 var blocksi = flag.Int("blocks", 4, "Blocks of X pixels")
 
-var x, y, z, angle float64
+var x, y, angle float64
 var comment string
 
 var wall *Sprite
@@ -23,28 +24,14 @@ var lamp *Sprite
 var ball *Sprite
 var err error
 
-const ww = 10
-const wh = 20
-
-var world [wh][ww]int
-
-const horizon float64 = 15
-const screenz float64 = 0.5 // for now...
+var level *raycast.Map
+var rc *raycast.Renderer
 
 func main() {
 	flag.Parse()
 	blocksw = 320
 	blocksh = 160
 	blocks = float64(*blocksi)
-	WHITE = NewColour(255, 255, 255, 255)
-	BLACK = NewColour(0, 0, 0, 255)
-	RED = NewColour(255, 0, 0, 255)
-	DARKRED = NewColour(139, 0, 0, 255)
-	GREEN = NewColour(0, 255, 0, 255)
-	BLUE = NewColour(0, 0, 255, 255)
-	GREY50 = NewColour(127, 127, 127, 255)
-	SADDLEBROWN = NewColour(139, 69, 19, 255)
-	STEELBLUE = NewColour(70, 130, 180, 255)
 	var ctx = New(blocks, blocksw, blocksh, "Dogenstein", nil)
 
 	onCreate(ctx)
@@ -58,24 +45,12 @@ func main() {
 	os.Exit(0)
 }
 
-type spriteObject struct {
-	sprite *Sprite
-	x, y   float64
-	dx, dy float64
-	alive  float64
-}
-
-var objects []spriteObject
-var bullets []spriteObject
-var depthbuffer *ZBuffer
-var zbuff []float64
+var objects []raycast.Billboard
 
 // GAME GLOBAL VARIABLES
 const FOV float64 = PI / 2
-const FOV2 float64 = FOV / 2
 
 var worldSpeed float64
-var distScreen = 0.5
 var commentTicker = 0.0
 
 func onCreate(c *Context) {
@@ -93,46 +68,46 @@ func onCreate(c *Context) {
 		panic("couldn't load sprite")
 	}
 
-	objects = []spriteObject{
-		spriteObject{sprite: lamp, x: 3, y: 4},
-		spriteObject{sprite: lamp, x: 4, y: 8},
-		spriteObject{sprite: lamp, x: 3, y: 12},
+	objects = []raycast.Billboard{
+		raycast.Billboard{Sprite: lamp, X: 3, Y: 4},
+		raycast.Billboard{Sprite: lamp, X: 4, Y: 8},
+		raycast.Billboard{Sprite: lamp, X: 3, Y: 12},
 	}
-	bullets = []spriteObject{}
 	c.Clear()
 	c.Present()
 	resetGame()
-	depthbuffer = NewZBuffer(blocksw, 1)
-	zbuff = make([]float64, int(blocksw))
+	rc = raycast.New(level, raycast.Textures{Wall: wall, Floor: colours.Red, Ceiling: colours.Black})
+	rc.Fog = 20
 }
 
 func resetGame() {
 	x = 3
 	y = 3
 	angle = PI / 2
-	world = [wh][ww]int{ // y then x
-		[ww]int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
-		[ww]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-		[ww]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-		[ww]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-		[ww]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-		[ww]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-		[ww]int{1, 0, 0, 0, 0, 1, 0, 1, 0, 1},
-		[ww]int{1, 0, 0, 0, 0, 1, 0, 1, 0, 1},
-		[ww]int{1, 0, 0, 0, 0, 1, 0, 1, 0, 1},
-		[ww]int{1, 0, 0, 0, 0, 1, 0, 1, 0, 1},
-		[ww]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-		[ww]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-		[ww]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-		[ww]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-		[ww]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-		[ww]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-		[ww]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-		[ww]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-		[ww]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
-		[ww]int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
-	}
-
+	level = raycast.NewMap([][]int{ // y then x
+		[]int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		[]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		[]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		[]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		[]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		[]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		[]int{1, 0, 0, 0, 0, 1, 2, 1, 0, 1},
+		[]int{1, 0, 0, 0, 0, 1, 0, 1, 0, 1},
+		[]int{1, 0, 0, 0, 0, 1, 0, 1, 0, 1},
+		[]int{1, 0, 0, 0, 0, 1, 0, 1, 0, 1},
+		[]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		[]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		[]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		[]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		[]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		[]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		[]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		[]int{1, 0, 3, 0, 0, 0, 0, 0, 0, 1},
+		[]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		[]int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+	})
+	level.SetType(2, raycast.CellType{Kind: raycast.Door, AlongX: true})
+	level.SetType(3, raycast.CellType{Kind: raycast.Wall, Height: 2})
 }
 
 func onUpdate(c *Context, elapsed float64) (running bool) {
@@ -152,9 +127,8 @@ func onUpdate(c *Context, elapsed float64) (running bool) {
 	}
 
 	// Screen prep and update code here...
-	c.SetDrawColor(BLACK)
+	c.SetDrawColor(colours.Black)
 	c.Clear()
-	depthbuffer.Clear()
 
 	// keys //////////////////////////////////////////
 	if keys.Key == "a" {
@@ -190,148 +164,28 @@ func onUpdate(c *Context, elapsed float64) (running bool) {
 		nx = x + math.Cos(angle)*ews
 		ny = y - math.Sin(angle)*ews
 	}
-	nx = Clamp(nx, 0, float64(ww))
-	ny = Clamp(ny, 0, float64(wh))
-
-	if world[int(math.Floor(ny))][int(math.Floor(nx))] == 0 {
-		x = nx
-		y = ny
-	} else {
+	var bumped bool
+	x, y, bumped = level.TryMove(x, y, nx, ny)
+	if bumped {
 		comment = "BUMP! Ooops"
 		commentTicker = 20.0
 	}
 
-	if keys.Key == " " {
+	// open or close a door in front of us
+	if keys.Key == " " && keys.Released {
+		if door := level.Door(int(x+math.Sin(angle)), int(y+math.Cos(angle))); door != nil {
+			door.Toggle()
+		}
 	}
 
 	// world manipulations /////////////////////////////////////
+	level.Update(elapsed)
 
 	// screen draw /////////////////////////////////////////////
-	screenmid := blocksh / 2
-
-	for bx := 0.0; bx < blocksw; bx++ {
-		a := angle + bx/blocksw*FOV - FOV2
-		// march a ray from screen distance to horizon
-		// z is distance to hitting a block. give up at horizon
-		z = screenz
-		var tx, ty float64
-		//hitWall := false
-		for z < horizon {
-			z += 0.01
-			tx = x + math.Sin(a)*z
-			ty = y + math.Cos(a)*z
-			if tx >= ww || tx < 0 || ty >= wh || ty < 0 {
-				z = horizon
-				break
-			}
-			if world[int(ty)][int(tx)] == 1 {
-				// hit a wall
-				//hitWall = true
-				break
-			}
-		}
-
-		// draw from top to bottom
-		wallt := math.Trunc(screenmid - (screenmid / z))
-		wallb := math.Trunc(blocksh - wallt)
-		c.SetDrawColor(BLACK) // ceiling to walltop
-		for by := 0.0; by < blocksh; by++ {
-			// from top to wall top
-			// nothing - keep black
-
-			// wall top to bottom (textured)
-			if by >= wallt && by < wallb {
-
-				// Texture Draw
-				// find normalised x and y to sample texture with
-				ny := (by - wallt) / (wallb - wallt)
-				var nx float64
-				// workout which side of cube hit
-				cx := math.Trunc(tx) + 0.5
-				cy := math.Trunc(ty) + 0.5
-
-				// line to eye
-				le := ty - cy
-				// line to ray point
-				lr := tx - cx
-				// angle between
-				wangle := math.Atan2(le, lr)
-				const PI4 = PI / 4
-				const PI3_4 = 0.75 * PI
-				// angle (less pi/4) as rotate axis a bit to indicate which side is hit
-				// +pi side (clockwise 2 quadrants)
-				if wangle >= -PI4 && wangle < PI4 {
-					nx = ty - math.Trunc(ty)
-				}
-				if wangle >= PI4 && wangle < PI3_4 {
-					nx = tx - math.Trunc(tx)
-				}
-				// -PI side (anti-clockwise quadrants)
-				if wangle < -PI4 && wangle >= -PI3_4 {
-					nx = tx - math.Trunc(tx)
-				}
-				if wangle >= PI3_4 || wangle < -PI3_4 {
-					nx = ty - math.Trunc(ty)
-				}
-				c.SetDrawColor(wall.SampleSprite(nx, ny))
-			}
-			// from wall to bottom
-			if by >= wallb {
-				c.SetDrawColor(RED.Fade(1 - (blocksh-by)/screenmid))
-			}
-			c.Point(bx, by)
-		}
-		// Set depth buffer to aid sprite manipulations later
-		zbuff[int(bx)] = z
-	}
-
-	// unit vector of player looking angle
-	eyex := math.Sin(angle)
-	eyey := math.Cos(angle)
-
-	// Draw static objects - sprites
-	// TODO draw sdynamic objects - bullets
-	for _, o := range objects {
-
-		// is object in field of view?
-		oVecx := o.x - x
-		oVecy := o.y - y
-		z := math.Sqrt(oVecx*oVecx + oVecy*oVecy)
-		oAngle := math.Atan2(eyey, eyex) - math.Atan2(oVecy, oVecx)
-		if oAngle < -PI {
-			oAngle += PI * 2
-		}
-		if oAngle > PI {
-			oAngle -= PI * 2
-		}
-		if math.Abs(oAngle) < FOV2 && z >= 0.5 && z < horizon {
-			// draw sprite
-			oCeil := screenmid - (blocksh / z)
-			oFloor := blocksh - oCeil
-			oHeight := oFloor - oCeil
-			oAspectRatio := o.sprite.H / o.sprite.W
-			oWidth := oHeight / oAspectRatio
-			oMidObject := (0.5*(oAngle/FOV2) + 0.5) * blocksw
-			for lx := 0.0; lx < oWidth; lx++ {
-				for ly := 0.0; ly < oHeight; ly++ {
-					sampleX := lx / oWidth
-					sampleY := ly / oHeight
-					clr := o.sprite.SampleSprite(sampleX, sampleY)
-					oCol := oMidObject + lx - (oWidth / 2)
-					if oCol >= 0 && oCol < blocksw {
-						if clr.A > 0 && zbuff[int(oCol)] >= z {
-							c.SetDrawColor(clr)
-							c.Point(oCol, ly+oCeil)
-							zbuff[int(oCol)] = z
-						}
-					}
-				}
-			}
-		}
-	}
+	rc.Render(c, raycast.Camera{X: x, Y: y, Angle: angle, FOV: FOV}, objects)
 
 	// Draw text and 'top' layers //////////////////////////////
-	c.SetDrawColor(STEELBLUE)
+	c.SetDrawColor(colours.SteelBlue)
 	if *fps {
 		c.DrawText(1, 17, 4, fmt.Sprintf("fps:%d", int(100/elapsed)))
 	}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"image/png"
+	"os"
+
+	"github.com/veandco/go-sdl2/sdl"
+
+	. "github.com/kevincolyer/GameEngine/GameEngine"
+	"github.com/kevincolyer/GameEngine/GameEngine/colours"
+)
+
+// A tiny paint program reading back the frame buffer: left button draws, right button fills, 1-8 pick a colour,
+// c clears, s saves paint.png and q quits
+
+var blocksw, blocksh, blocks float64
+var palette = []Colour{colours.Black, colours.White, colours.Red, colours.Orange, colours.Yellow,
+	colours.ForestGreen, colours.RoyalBlue, colours.SaddleBrown}
+var ink = 0
+var lastX, lastY float64
+var drawing bool
+
+func main() {
+	blocksw = 160
+	blocksh = 120
+	blocks = 4
+	var ctx = New(blocks, blocksw, blocksh, "Paint", nil)
+
+	onCreate(ctx)
+	var running = true
+
+	for running {
+		running = onUpdate(ctx, ctx.Elapsed())
+	}
+
+	ctx.Destroy()
+	os.Exit(0)
+}
+
+func onCreate(c *Context) {
+	c.SetDrawColor(colours.White)
+	c.Clear()
+	c.Present()
+}
+
+func save(c *Context) {
+	f, err := os.Create("paint.png")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer f.Close()
+	if err = png.Encode(f, c.Snapshot()); err != nil {
+		fmt.Println(err)
+	}
+}
+
+func onUpdate(c *Context, elapsed float64) (running bool) {
+	running, keys := c.PollQuitandKeys()
+	if keys.Event && keys.Released {
+		switch k := keys.Key; {
+		case k == "q":
+			running = false
+		case k == "c":
+			c.SetDrawColor(colours.White)
+			c.Clear()
+		case k == "s":
+			save(c)
+		case len(k) == 1 && k[0] >= '1' && k[0] <= '8':
+			ink = int(k[0] - '1')
+		}
+	}
+
+	mx, my, state := sdl.GetMouseState()
+	x, y := float64(mx)/blocks, float64(my)/blocks
+	c.SetDrawColor(palette[ink])
+	switch {
+	case state&sdl.ButtonLMask() != 0:
+		if !drawing {
+			lastX, lastY = x, y
+		}
+		c.StyledLine(lastX, lastY, x, y, LineStyle{Width: 2, Cap: CapRound, Join: JoinRound})
+		lastX, lastY, drawing = x, y, true
+	case state&sdl.ButtonRMask() != 0:
+		if c.GetPoint(x, y) != palette[ink] {
+			c.FloodFill(x, y, 0)
+		}
+		drawing = false
+	default:
+		drawing = false
+	}
+	c.Present()
+	return
+}
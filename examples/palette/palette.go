@@ -0,0 +1,132 @@
+package main
+
+import (
+	"math"
+	"os"
+
+	. "github.com/kevincolyer/GameEngine/GameEngine"
+	"github.com/kevincolyer/GameEngine/GameEngine/colours"
+)
+
+// Palette cycling in indexed colour: a waterfall and a lava pool that only move because their palette entries turn.
+// 1-4 swap palettes, f fades to black and back, space stops the cycling, q quits
+
+var blocksw, blocksh float64
+
+// palette entries 0-15 are the scene, 16-23 the water and 24-31 the lava
+const (
+	water = 16
+	lava  = 24
+	ramp  = 8
+)
+
+var waterRamp, lavaRamp []Colour
+var palettes [][]Colour
+var fading bool
+var fade float64
+var cycling = true
+
+func main() {
+	blocksw = 160
+	blocksh = 120
+	var ctx = New(4, blocksw, blocksh, "Palette", nil)
+
+	onCreate(ctx)
+	var running = true
+
+	for running {
+		running = onUpdate(ctx, ctx.Elapsed())
+	}
+
+	ctx.Destroy()
+	os.Exit(0)
+}
+
+// strict - PICO-8 scene colours and the ramps, all snapped to the colours of base
+func strict(base *colours.Palette) (pal []Colour) {
+	for _, c := range colours.PICO8.Colours {
+		pal = append(pal, base.Colours[base.Nearest(c)])
+	}
+	for _, c := range append(append([]Colour{}, waterRamp...), lavaRamp...) {
+		pal = append(pal, base.Colours[base.Nearest(c)])
+	}
+	return
+}
+
+func onCreate(c *Context) {
+	waters := Gradient{{0, colours.MidnightBlue}, {0.5, colours.DodgerBlue}, {0.8, colours.LightCyan}, {1, colours.MidnightBlue}}
+	lavas := Gradient{{0, colours.DarkRed}, {0.5, colours.OrangeRed}, {0.8, colours.Gold}, {1, colours.DarkRed}}
+	for i := 0; i < ramp; i++ {
+		t := float64(i) / ramp
+		waterRamp = append(waterRamp, waters.At(t))
+		lavaRamp = append(lavaRamp, lavas.At(t))
+	}
+	full := append(append(append([]Colour{}, colours.PICO8.Colours...), waterRamp...), lavaRamp...)
+	palettes = [][]Colour{full, strict(colours.PICO8), strict(colours.CGA), strict(colours.GameBoy)}
+
+	c.SetIndexed(palettes[0])
+	c.AddPaletteCycle(water, water+ramp-1, 0.15)
+	c.AddPaletteCycle(lava, lava+ramp-1, -0.08)
+	c.Clear()
+	c.Present()
+}
+
+func onUpdate(c *Context, elapsed float64) (running bool) {
+	running, keys := c.PollQuitandKeys()
+	if keys.Event && keys.Released {
+		switch keys.Key {
+		case "q":
+			running = false
+		case "1", "2", "3", "4":
+			c.SetPalette(palettes[keys.Key[0]-'1'])
+		case "f":
+			fading = !fading
+		case " ":
+			cycling = !cycling
+			if cycling {
+				c.AddPaletteCycle(water, water+ramp-1, 0.15)
+				c.AddPaletteCycle(lava, lava+ramp-1, -0.08)
+			} else {
+				c.ClearPaletteCycles()
+			}
+		}
+	}
+	if fading {
+		fade = math.Min(fade+elapsed/100, 1)
+	} else {
+		fade = math.Max(fade-elapsed/100, 0)
+	}
+	c.FadePalette(colours.Black, fade)
+
+	// night sky and cliffs, drawn with colours which snap to the palette
+	c.SetDrawIndex(1)
+	c.Clear()
+	c.SetDrawColor(colours.PICO8.Colours[5])
+	for x := 0.0; x < blocksw; x++ {
+		if x < 60 || x > 84 {
+			top := 30 + 8*math.Sin(x/9)
+			c.Line(x, top, x, blocksh)
+		}
+	}
+	c.SetDrawColor(colours.PICO8.Colours[7])
+	c.DrawFillCircle(130, 15, 6)
+
+	// the waterfall: bands of the water entries going down, so turning them makes it fall
+	for y := 20.0; y < 96; y++ {
+		for x := 60.0; x <= 84; x++ {
+			c.SetDrawIndex(water + int(y-x/6)%ramp)
+			c.Point(x, y)
+		}
+	}
+	// the lava pool
+	for y := 96.0; y < blocksh; y++ {
+		for x := 0.0; x < blocksw; x++ {
+			c.SetDrawIndex(lava + int(x/3+y/2)%ramp)
+			c.Point(x, y)
+		}
+	}
+	c.SetDrawIndex(7)
+	c.DrawText(2, 2, 1, "1-4 PALETTE  F FADE")
+	c.Present()
+	return
+}
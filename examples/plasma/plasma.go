@@ -0,0 +1,47 @@
+package main
+
+import (
+	"math"
+	"os"
+
+	. "github.com/kevincolyer/GameEngine/GameEngine"
+)
+
+// Old school plasma, every block worked out every frame, a row per goroutine. q quits
+
+var blocksw, blocksh float64
+var t float64
+
+func main() {
+	blocksw = 320
+	blocksh = 200
+	var ctx = New(3, blocksw, blocksh, "Plasma", nil)
+
+	var running = true
+	for running {
+		running = onUpdate(ctx, ctx.Elapsed())
+	}
+
+	ctx.Destroy()
+	os.Exit(0)
+}
+
+func onUpdate(c *Context, elapsed float64) (running bool) {
+	running, keys := c.PollQuitandKeys()
+	if keys.Event && keys.Key == "q" {
+		running = false
+	}
+	t += elapsed / 50
+
+	c.ParallelRows(func(s *Surface, y int) {
+		fy := float64(y) / 16
+		for x := 0; x < s.W; x++ {
+			fx := float64(x) / 16
+			v := math.Sin(fx+t) + math.Sin((fy+t)/2) + math.Sin((fx+fy+t)/2) +
+				math.Sin(math.Hypot(fx-10+5*math.Sin(t/3), fy-6+3*math.Cos(t/2)))
+			s.Set(x, y, NewColourHSV(v*90+t*20, 0.8, 1, 255))
+		}
+	})
+	c.Present()
+	return
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 
 	. "github.com/kevincolyer/GameEngine/GameEngine"
+	"github.com/kevincolyer/GameEngine/GameEngine/colours"
 )
 
 // helper function - can be passed in with GameEngine.New to modify the way blocks are drawn to the screen
@@ -17,7 +18,9 @@ func wrapScreen(x, y float64) (float64, float64) {
 }
 
 var blocksw, blocksh, blocks float64
-var WHITE, BLACK, RED, GREEN, BLUE, GREY50, DARKRED, SADDLEBROWN, BROWN, STEELBLUE Colour
+
+// BROWN - darker than X11 brown, which is nearly red
+var BROWN = NewColour(101, 60, 15, 255)
 var explodeShip bool
 
 var fps = flag.Bool("fps", false, "Display Frames per second")
@@ -32,16 +35,6 @@ func main() {
 	blocksw = 320
 	blocksh = 160
 	blocks = float64(*blocksi)
-	WHITE = NewColour(255, 255, 255, 255)
-	BLACK = NewColour(0, 0, 0, 255)
-	RED = NewColour(255, 0, 0, 255)
-	DARKRED = NewColour(139, 0, 0, 255)
-	GREEN = NewColour(0, 255, 0, 255)
-	BLUE = NewColour(0, 0, 255, 255)
-	GREY50 = NewColour(127, 127, 127, 255)
-	SADDLEBROWN = NewColour(139, 69, 19, 255)
-	BROWN = NewColour(101, 60, 15, 255)
-	STEELBLUE = NewColour(70, 130, 180, 255)
 	var ctx = New(blocks, blocksw, blocksh, "SSSSNake!", wrapScreen)
 
 	onCreate(ctx)
@@ -104,7 +97,7 @@ func onUpdate(c *Context, elapsed float64) (running bool) {
 		}
 	}
 	// Update code here...
-	c.SetDrawColor(BLACK)
+	c.SetDrawColor(colours.Black)
 	c.Clear()
 	// keys //////////////////////////////////////////
 	if keys.Key == "a" {
@@ -152,21 +145,21 @@ func onUpdate(c *Context, elapsed float64) (running bool) {
 
 	for i := player.length - 1; i >= 1.0; i-- {
 		if math.Mod(i, 2) == 0 {
-			c.SetDrawColor(SADDLEBROWN)
+			c.SetDrawColor(colours.SaddleBrown)
 		} else {
 			c.SetDrawColor(BROWN)
 		}
 		c.DrawFillCircle(player.segments[int(i)].x, player.segments[int(i)].y, player.size)
 	}
-	c.SetDrawColor(WHITE)
+	c.SetDrawColor(colours.White)
 	c.DrawFillCircle(player.segments[0].x, player.segments[0].y, player.size)
-	c.SetDrawColor(SADDLEBROWN)
+	c.SetDrawColor(colours.SaddleBrown)
 	// eyes
 	c.Point(player.segments[0].x+math.Cos(player.direction)*2, player.segments[0].y-math.Sin(player.direction)*2)
 	c.Point(player.segments[0].x-math.Cos(player.direction)*2, player.segments[0].y+math.Sin(player.direction)*2)
 
 	// Draw text and 'top' layers
-	c.SetDrawColor(DARKRED)
+	c.SetDrawColor(colours.DarkRed)
 	c.DrawText(1, 1, 2, fmt.Sprintf("hi:%v score:%v", hiscore, score))
 	if *fps {
 		c.DrawText(1, 17, 4, fmt.Sprintf("fps:%d", int(100/elapsed)))